@@ -0,0 +1,281 @@
+// Package archive keeps the history of day files in an embedded store with tables of days, meals,
+// dishes and ingredient occurrences. The store is a single JSON file, so it needs no database server
+// or C library; files are imported incrementally, keyed by the hash of their content.
+package archive
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/toszr/dietician/meal"
+	"github.com/toszr/dietician/parser"
+)
+
+// FormatVersion is the version of the store file written by Save
+const FormatVersion = 1
+
+// dateLayout is the layout of dates in the store
+const dateLayout = "2006-01-02"
+
+// ErrUnknownFormatVersion is returned when the store was written by a newer version
+var ErrUnknownFormatVersion = errors.New("unknown archive format version")
+
+// Day is an imported day file with the profile of its plan
+type Day struct {
+	ID       int       `json:"id"`
+	Date     string    `json:"date,omitempty"`
+	Person   string    `json:"person,omitempty"`
+	Variant  string    `json:"variant,omitempty"`
+	Calories int       `json:"calories,omitempty"`
+	Source   string    `json:"source"`
+	Hash     string    `json:"hash"`
+	Imported time.Time `json:"imported"`
+}
+
+// Profile returns whose plan the day is
+func (d Day) Profile() meal.Profile {
+	return meal.Profile{Person: d.Person, Variant: d.Variant, Calories: d.Calories}
+}
+
+// Meal is a meal of a day
+type Meal struct {
+	ID       int    `json:"id"`
+	DayID    int    `json:"dayId"`
+	Position int    `json:"position"`
+	Name     string `json:"name"`
+}
+
+// Dish is a dish of a meal with the provider's tags and instructions
+type Dish struct {
+	ID           int      `json:"id"`
+	MealID       int      `json:"mealId"`
+	Position     int      `json:"position"`
+	Name         string   `json:"name"`
+	Tags         []string `json:"tags,omitempty"`
+	Instructions []string `json:"instructions,omitempty"`
+}
+
+// Occurrence is an ingredient of a dish, or a component of one. Position is the path from
+// the top-level ingredient as in table.Ingredients, e.g. "3.2", and Parent the name it is a component of.
+// Top-level ingredients keep their Text as printed in the menu, so plans can be rebuilt from the archive.
+type Occurrence struct {
+	DishID     int     `json:"dishId"`
+	Position   string  `json:"position"`
+	Text       string  `json:"text,omitempty"`
+	Name       string  `json:"name"`
+	Canonical  string  `json:"canonical"`
+	Parent     string  `json:"parent,omitempty"`
+	Percentage float64 `json:"percentage,omitempty"`
+}
+
+// Archive is the store of imported days
+type Archive struct {
+	Version     int          `json:"version"`
+	Days        []Day        `json:"days"`
+	Meals       []Meal       `json:"meals"`
+	Dishes      []Dish       `json:"dishes"`
+	Occurrences []Occurrence `json:"ingredientOccurrences"`
+
+	path string
+}
+
+// ImportStatus tells what importing a file changed
+type ImportStatus int
+
+const (
+	// Unchanged means the file was imported before with the same content
+	Unchanged ImportStatus = iota
+	// Added means the day was not in the archive
+	Added
+	// Replaced means the day was imported before from different content
+	Replaced
+)
+
+// Open reads the archive stored at path, or returns an empty one if the file does not exist yet
+func Open(path string) (*Archive, error) {
+	a := &Archive{Version: FormatVersion, path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return a, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, a); err != nil {
+		return nil, fmt.Errorf("archive %s: %w", path, err)
+	}
+	if a.Version != FormatVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownFormatVersion, a.Version)
+	}
+	return a, nil
+}
+
+// Save writes the archive back to its file, replacing it only once the new content is complete
+func (a *Archive) Save() error {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(a.path), filepath.Base(a.path)+".*")
+	if err != nil {
+		return err
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), a.path)
+}
+
+// ImportFile imports a day file unless a file with the same content was imported before.
+// A day imported earlier, identified by its date and profile or, without a date, by the file path, is replaced.
+func (a *Archive) ImportFile(path string) (ImportStatus, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Unchanged, err
+	}
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	for _, d := range a.Days {
+		if d.Hash == hash {
+			return Unchanged, nil
+		}
+	}
+
+	plan, err := parser.ParseFile(path)
+	if err != nil {
+		return Unchanged, err
+	}
+	day := Day{
+		Source:   filepath.ToSlash(path),
+		Hash:     hash,
+		Imported: time.Now().UTC(),
+		Person:   plan.Profile.Person,
+		Variant:  plan.Profile.Variant,
+		Calories: plan.Profile.Calories,
+	}
+	if !plan.Date.IsZero() {
+		day.Date = plan.Date.Format(dateLayout)
+	}
+
+	status := Added
+	for _, d := range a.Days {
+		if (day.Date != "" && d.Date == day.Date && d.Profile().Slug() == day.Profile().Slug()) || (day.Date == "" && d.Date == "" && d.Source == day.Source) {
+			a.remove(d.ID)
+			status = Replaced
+			break
+		}
+	}
+	a.add(day, plan)
+	return status, nil
+}
+
+// add inserts the rows of a day
+func (a *Archive) add(day Day, plan meal.Plan) {
+	day.ID = a.nextDayID()
+	a.Days = append(a.Days, day)
+
+	mealID, dishID := a.nextMealID(), a.nextDishID()
+	for i, m := range plan.Meals {
+		a.Meals = append(a.Meals, Meal{ID: mealID, DayID: day.ID, Position: i + 1, Name: m.Name})
+		for j, d := range m.Dishes {
+			a.Dishes = append(a.Dishes, Dish{ID: dishID, MealID: mealID, Position: j + 1, Name: d.Name, Tags: d.Tags, Instructions: d.Instructions})
+			a.addOccurrences(dishID, meal.ParseIngredients(d.Ingredients), "", "")
+			dishID++
+		}
+		mealID++
+	}
+}
+
+func (a *Archive) addOccurrences(dishID int, ings []meal.Ingredient, position, parent string) {
+	for i, ing := range ings {
+		pos := strconv.Itoa(i + 1)
+		if position != "" {
+			pos = position + "." + pos
+		}
+		occurrence := Occurrence{
+			DishID:     dishID,
+			Position:   pos,
+			Name:       ing.Name,
+			Canonical:  meal.CanonicalName(ing.Name),
+			Parent:     parent,
+			Percentage: ing.Percentage,
+		}
+		if parent == "" {
+			occurrence.Text = ing.Text
+		}
+		a.Occurrences = append(a.Occurrences, occurrence)
+		a.addOccurrences(dishID, ing.Components, pos, ing.Name)
+	}
+}
+
+// remove deletes a day with its meals, dishes and ingredient occurrences
+func (a *Archive) remove(dayID int) {
+	meals := map[int]bool{}
+	dishes := map[int]bool{}
+	a.Days = filter(a.Days, func(d Day) bool { return d.ID != dayID })
+	a.Meals = filter(a.Meals, func(m Meal) bool {
+		if m.DayID == dayID {
+			meals[m.ID] = true
+		}
+		return m.DayID != dayID
+	})
+	a.Dishes = filter(a.Dishes, func(d Dish) bool {
+		if meals[d.MealID] {
+			dishes[d.ID] = true
+		}
+		return !meals[d.MealID]
+	})
+	a.Occurrences = filter(a.Occurrences, func(o Occurrence) bool { return !dishes[o.DishID] })
+}
+
+func filter[T any](rows []T, keep func(T) bool) []T {
+	kept := rows[:0]
+	for _, row := range rows {
+		if keep(row) {
+			kept = append(kept, row)
+		}
+	}
+	return kept
+}
+
+func (a *Archive) nextDayID() int {
+	id := 0
+	for _, d := range a.Days {
+		id = max(id, d.ID)
+	}
+	return id + 1
+}
+
+func (a *Archive) nextMealID() int {
+	id := 0
+	for _, m := range a.Meals {
+		id = max(id, m.ID)
+	}
+	return id + 1
+}
+
+func (a *Archive) nextDishID() int {
+	id := 0
+	for _, d := range a.Dishes {
+		id = max(id, d.ID)
+	}
+	return id + 1
+}
@@ -0,0 +1,195 @@
+package archive
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/toszr/dietician/meal"
+	"github.com/toszr/dietician/table"
+)
+
+const day1 = `# Śniadanie
+
+## Owsianka
+**Składniki:**
+- Płatki owsiane
+- Mleko 2%
+
+# Obiad
+
+## Zupa pomidorowa
+**Składniki:**
+- Bulion warzywny (woda, marchew 20%)
+- Pomidory pelati
+
+`
+
+const day2 = `# Śniadanie
+
+## Owsianka
+**Składniki:**
+- Płatki owsiane
+
+`
+
+// writeDay writes a day file into dir and returns its path
+func writeDay(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+// testArchive returns an archive in a temporary directory with both days imported
+func testArchive(t *testing.T) *Archive {
+	t.Helper()
+	dir := t.TempDir()
+	a, err := Open(filepath.Join(dir, "archive.json"))
+	require.NoError(t, err)
+	for _, f := range []struct{ name, content string }{{"020126.md", day2}, {"010126.md", day1}} {
+		status, err := a.ImportFile(writeDay(t, dir, f.name, f.content))
+		require.NoError(t, err)
+		require.Equal(t, Added, status)
+	}
+	return a
+}
+
+func TestImportFile(t *testing.T) {
+	t.Run("tables", func(t *testing.T) {
+		a := testArchive(t)
+
+		assert.Len(t, a.Days, 2)
+		assert.Len(t, a.Meals, 3)
+		assert.Len(t, a.Dishes, 3)
+		assert.Len(t, a.Occurrences, 7)
+		assert.Contains(t, a.Occurrences, Occurrence{DishID: 3, Position: "1.2", Name: "marchew", Canonical: "marchew", Parent: "Bulion warzywny", Percentage: 20})
+	})
+
+	t.Run("incremental import", func(t *testing.T) {
+		dir := t.TempDir()
+		a, err := Open(filepath.Join(dir, "archive.json"))
+		require.NoError(t, err)
+		path := writeDay(t, dir, "010126.md", day1)
+
+		status, err := a.ImportFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, Added, status)
+
+		status, err = a.ImportFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, Unchanged, status)
+
+		writeDay(t, dir, "010126.md", day2)
+		status, err = a.ImportFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, Replaced, status)
+		assert.Len(t, a.Days, 1)
+		assert.Len(t, a.Meals, 1)
+		assert.Len(t, a.Dishes, 1)
+		assert.Len(t, a.Occurrences, 1)
+	})
+
+	t.Run("plans of different people for the same day", func(t *testing.T) {
+		dir := t.TempDir()
+		a, err := Open(filepath.Join(dir, "archive.json"))
+		require.NoError(t, err)
+
+		status, err := a.ImportFile(writeDay(t, dir, "010126-anna.md", "---\nperson: Anna\n---\n\n"+day1))
+		require.NoError(t, err)
+		assert.Equal(t, Added, status)
+		status, err = a.ImportFile(writeDay(t, dir, "010126-piotr.md", "---\nperson: Piotr\ncalories: 2000\n---\n\n"+day2))
+		require.NoError(t, err)
+		assert.Equal(t, Added, status)
+
+		plans := a.Plans()
+		require.Len(t, plans, 2)
+		assert.Equal(t, meal.Profile{Person: "Anna"}, plans[0].Profile)
+		assert.Equal(t, meal.Profile{Person: "Piotr", Calories: 2000}, plans[1].Profile)
+	})
+
+	t.Run("plans of different variants for the same day", func(t *testing.T) {
+		dir := t.TempDir()
+		a, err := Open(filepath.Join(dir, "archive.json"))
+		require.NoError(t, err)
+
+		status, err := a.ImportFile(writeDay(t, dir, "010126-wege-1500kcal.md", "---\nvariant: wege\ncalories: 1500\n---\n\n"+day1))
+		require.NoError(t, err)
+		assert.Equal(t, Added, status)
+		status, err = a.ImportFile(writeDay(t, dir, "010126-sport-2500kcal.md", "---\nvariant: sport\ncalories: 2500\n---\n\n"+day2))
+		require.NoError(t, err)
+		assert.Equal(t, Added, status)
+		status, err = a.ImportFile(writeDay(t, dir, "010126-wege-1500kcal-v2.md", "---\nvariant: Wege\ncalories: 1500\n---\n\n"+day2))
+		require.NoError(t, err)
+		assert.Equal(t, Replaced, status)
+
+		assert.Len(t, a.Plans(), 2)
+	})
+
+	t.Run("tags and instructions of dishes", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "archive.json")
+		a, err := Open(path)
+		require.NoError(t, err)
+
+		_, err = a.ImportFile(writeDay(t, dir, "010126.md", "# Obiad\n\n## Zupa pomidorowa\n*Tagi:* wege, nowość\n> **Wskazówki:** Podgrzać w garnku\n**Składniki:**\n- Pomidory pelati\n"))
+		require.NoError(t, err)
+		require.NoError(t, a.Save())
+		a, err = Open(path)
+		require.NoError(t, err)
+
+		dish := a.Plans()[0].Meals[0].Dishes[0]
+		assert.Equal(t, []string{"wege", "nowość"}, dish.Tags)
+		assert.Equal(t, []string{"Podgrzać w garnku"}, dish.Instructions)
+	})
+
+	t.Run("save and open", func(t *testing.T) {
+		a := testArchive(t)
+		require.NoError(t, a.Save())
+
+		reopened, err := Open(a.path)
+		require.NoError(t, err)
+		assert.Equal(t, a.Days, reopened.Days)
+		assert.Equal(t, a.Occurrences, reopened.Occurrences)
+	})
+
+	t.Run("unknown format version", func(t *testing.T) {
+		path := writeDay(t, t.TempDir(), "archive.json", `{"version": 99}`)
+		_, err := Open(path)
+		assert.ErrorIs(t, err, ErrUnknownFormatVersion)
+	})
+}
+
+func TestPlans(t *testing.T) {
+	plans := testArchive(t).Plans()
+
+	require.Len(t, plans, 2)
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), plans[0].Date)
+	assert.Equal(t, []meal.Dish{{Name: "Zupa pomidorowa", Ingredients: []string{"Bulion warzywny (woda, marchew 20%)", "Pomidory pelati"}}}, plans[0].Meals[1].Dishes)
+	assert.Equal(t, "Owsianka", plans[1].Meals[0].Dishes[0].Name)
+}
+
+func TestReports(t *testing.T) {
+	a := testArchive(t)
+	run := func(name, arg string) table.Table {
+		r, ok := ReportByName(name)
+		require.True(t, ok, name)
+		return r.Run(a, arg)
+	}
+
+	assert.Equal(t, [][]string{
+		{"Owsianka", "2", "2026-01-01", "2026-01-02"},
+		{"Zupa pomidorowa", "1", "2026-01-01", "2026-01-01"},
+	}, run("dishes", "").Rows)
+	assert.Equal(t, [][]string{{"Śniadanie", "2", "1"}, {"Obiad", "1", "1"}}, run("meals", "").Rows)
+	assert.Equal(t, []string{"płatki owsiane", "2", "2"}, run("ingredients", "").Rows[0])
+	assert.Equal(t, [][]string{{"2026-01-01", "Obiad", "Zupa pomidorowa", "marchew", "Bulion warzywny"}}, run("ingredient", "MARCHEW").Rows)
+	assert.Len(t, run("dish", "owsianka").Rows, 2)
+	assert.Len(t, run("days", "").Rows, 2)
+
+	_, ok := ReportByName("unknown")
+	assert.False(t, ok)
+}
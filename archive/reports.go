@@ -0,0 +1,241 @@
+package archive
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/toszr/dietician/meal"
+	"github.com/toszr/dietician/table"
+)
+
+// Report is a named query over the archive, taking an argument if Arg names one
+type Report struct {
+	Name        string
+	Arg         string
+	Description string
+	Run         func(a *Archive, arg string) table.Table
+}
+
+// Reports are the queries available to the query command
+var Reports = []Report{
+	{"days", "", "Imported days with the number of meals and dishes", (*Archive).daysReport},
+	{"meals", "", "Meals with the number of dishes served and of distinct dishes", (*Archive).mealsReport},
+	{"dishes", "", "Dishes by the number of servings, with the first and last day", (*Archive).dishesReport},
+	{"ingredients", "", "Top-level ingredients by the number of dishes using them", (*Archive).ingredientsReport},
+	{"dish", "TEXT", "Servings of dishes whose names contain TEXT", (*Archive).dishReport},
+	{"ingredient", "TEXT", "Dishes with an ingredient or component whose name contains TEXT", (*Archive).ingredientReport},
+}
+
+// ReportByName returns the report with the given name
+func ReportByName(name string) (Report, bool) {
+	for _, r := range Reports {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return Report{}, false
+}
+
+// serving is a dish joined with its meal and day
+type serving struct {
+	Day  Day
+	Meal Meal
+	Dish Dish
+}
+
+// servings returns every dish joined with its meal and day, in date and menu order
+func (a *Archive) servings() []serving {
+	days := map[int]Day{}
+	for _, d := range a.Days {
+		days[d.ID] = d
+	}
+	meals := map[int]Meal{}
+	for _, m := range a.Meals {
+		meals[m.ID] = m
+	}
+
+	result := make([]serving, 0, len(a.Dishes))
+	for _, d := range a.Dishes {
+		m := meals[d.MealID]
+		result = append(result, serving{Day: days[m.DayID], Meal: m, Dish: d})
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Day.Date != b.Day.Date {
+			return a.Day.Date < b.Day.Date
+		}
+		if a.Day.ID != b.Day.ID {
+			return a.Day.ID < b.Day.ID
+		}
+		if a.Meal.Position != b.Meal.Position {
+			return a.Meal.Position < b.Meal.Position
+		}
+		return a.Dish.Position < b.Dish.Position
+	})
+	return result
+}
+
+// Plans rebuilds the plans of all imported days, sorted by date
+func (a *Archive) Plans() []meal.Plan {
+	ingredients := map[int][]string{}
+	for _, o := range a.Occurrences {
+		if o.Parent == "" {
+			ingredients[o.DishID] = append(ingredients[o.DishID], o.Text)
+		}
+	}
+
+	var plans []meal.Plan
+	var lastDay, lastMeal = -1, -1
+	for _, s := range a.servings() {
+		if s.Day.ID != lastDay {
+			date, _ := time.Parse(dateLayout, s.Day.Date)
+			plans = append(plans, meal.Plan{Date: date, Profile: s.Day.Profile()})
+			lastDay, lastMeal = s.Day.ID, -1
+		}
+		plan := &plans[len(plans)-1]
+		if s.Meal.ID != lastMeal {
+			plan.Meals = append(plan.Meals, meal.Meal{Name: s.Meal.Name})
+			lastMeal = s.Meal.ID
+		}
+		m := &plan.Meals[len(plan.Meals)-1]
+		m.Dishes = append(m.Dishes, meal.Dish{Name: s.Dish.Name, Ingredients: ingredients[s.Dish.ID], Tags: s.Dish.Tags, Instructions: s.Dish.Instructions})
+	}
+	return plans
+}
+
+func (a *Archive) daysReport(string) table.Table {
+	t := table.Table{Header: []string{"date", "source", "meals", "dishes"}}
+	meals := map[int]int{}
+	for _, m := range a.Meals {
+		meals[m.DayID]++
+	}
+	dishes := map[int]int{}
+	for _, s := range a.servings() {
+		dishes[s.Day.ID]++
+	}
+	days := append([]Day{}, a.Days...)
+	sort.SliceStable(days, func(i, j int) bool { return days[i].Date < days[j].Date })
+	for _, d := range days {
+		t.Rows = append(t.Rows, []string{d.Date, d.Source, strconv.Itoa(meals[d.ID]), strconv.Itoa(dishes[d.ID])})
+	}
+	return t
+}
+
+func (a *Archive) mealsReport(string) table.Table {
+	t := table.Table{Header: []string{"meal", "dishes", "distinct"}}
+	var order []string
+	counts := map[string]int{}
+	distinct := map[string]map[string]bool{}
+	for _, s := range a.servings() {
+		if counts[s.Meal.Name] == 0 {
+			order = append(order, s.Meal.Name)
+			distinct[s.Meal.Name] = map[string]bool{}
+		}
+		counts[s.Meal.Name]++
+		distinct[s.Meal.Name][meal.CanonicalName(s.Dish.Name)] = true
+	}
+	for _, name := range order {
+		t.Rows = append(t.Rows, []string{name, strconv.Itoa(counts[name]), strconv.Itoa(len(distinct[name]))})
+	}
+	return t
+}
+
+func (a *Archive) dishesReport(string) table.Table {
+	t := table.Table{Header: []string{"dish", "servings", "first", "last"}}
+	type stats struct {
+		name        string
+		servings    int
+		first, last string
+	}
+	byName := map[string]*stats{}
+	for _, s := range a.servings() {
+		key := meal.CanonicalName(s.Dish.Name)
+		st, ok := byName[key]
+		if !ok {
+			st = &stats{first: s.Day.Date}
+			byName[key] = st
+		}
+		st.name = s.Dish.Name
+		st.servings++
+		st.last = s.Day.Date
+	}
+	all := make([]*stats, 0, len(byName))
+	for _, st := range byName {
+		all = append(all, st)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].servings != all[j].servings {
+			return all[i].servings > all[j].servings
+		}
+		return all[i].name < all[j].name
+	})
+	for _, st := range all {
+		t.Rows = append(t.Rows, []string{st.name, strconv.Itoa(st.servings), st.first, st.last})
+	}
+	return t
+}
+
+func (a *Archive) ingredientsReport(string) table.Table {
+	t := table.Table{Header: []string{"ingredient", "dishes", "days"}}
+	dayOf := map[int]int{}
+	for _, s := range a.servings() {
+		dayOf[s.Dish.ID] = s.Day.ID
+	}
+	dishes := map[string]map[int]bool{}
+	days := map[string]map[int]bool{}
+	for _, o := range a.Occurrences {
+		if o.Parent != "" || o.Canonical == "" {
+			continue
+		}
+		if dishes[o.Canonical] == nil {
+			dishes[o.Canonical] = map[int]bool{}
+			days[o.Canonical] = map[int]bool{}
+		}
+		dishes[o.Canonical][o.DishID] = true
+		days[o.Canonical][dayOf[o.DishID]] = true
+	}
+	names := make([]string, 0, len(dishes))
+	for name := range dishes {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if len(dishes[names[i]]) != len(dishes[names[j]]) {
+			return len(dishes[names[i]]) > len(dishes[names[j]])
+		}
+		return names[i] < names[j]
+	})
+	for _, name := range names {
+		t.Rows = append(t.Rows, []string{name, strconv.Itoa(len(dishes[name])), strconv.Itoa(len(days[name]))})
+	}
+	return t
+}
+
+func (a *Archive) dishReport(text string) table.Table {
+	t := table.Table{Header: []string{"date", "meal", "dish"}}
+	text = meal.CanonicalName(text)
+	for _, s := range a.servings() {
+		if strings.Contains(meal.CanonicalName(s.Dish.Name), text) {
+			t.Rows = append(t.Rows, []string{s.Day.Date, s.Meal.Name, s.Dish.Name})
+		}
+	}
+	return t
+}
+
+func (a *Archive) ingredientReport(text string) table.Table {
+	t := table.Table{Header: []string{"date", "meal", "dish", "ingredient", "parent"}}
+	text = meal.CanonicalName(text)
+	occurrences := map[int][]Occurrence{}
+	for _, o := range a.Occurrences {
+		if strings.Contains(o.Canonical, text) {
+			occurrences[o.DishID] = append(occurrences[o.DishID], o)
+		}
+	}
+	for _, s := range a.servings() {
+		for _, o := range occurrences[s.Dish.ID] {
+			t.Rows = append(t.Rows, []string{s.Day.Date, s.Meal.Name, s.Dish.Name, o.Name, o.Parent})
+		}
+	}
+	return t
+}
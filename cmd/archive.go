@@ -0,0 +1,110 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/toszr/dietician/archive"
+	"github.com/toszr/dietician/table"
+)
+
+// defaultArchivePath is the store used by the archive and query commands
+const defaultArchivePath = "archive.json"
+
+// runArchive imports day files into the archive, skipping files imported before with the same content.
+// Arguments are files or directories, samples/ by default.
+func runArchive(args []string) {
+	flags := flag.NewFlagSet("archive", flag.ExitOnError)
+	dbPath := flags.String("db", defaultArchivePath, "Path to the archive")
+	flags.Parse(args)
+
+	paths := flags.Args()
+	if len(paths) == 0 {
+		paths = []string{"samples"}
+	}
+
+	a, err := archive.Open(*dbPath)
+	if err != nil {
+		log.Fatalf("Failed to open archive: %v", err)
+	}
+
+	counts := map[archive.ImportStatus]int{}
+	for _, path := range paths {
+		files, err := dayFiles(path)
+		if err != nil {
+			log.Fatalf("Failed to list day files in '%s': %v", path, err)
+		}
+		for _, file := range files {
+			status, err := a.ImportFile(file)
+			if err != nil {
+				log.Printf("Failed to import '%s': %v", file, err)
+				continue
+			}
+			counts[status]++
+		}
+	}
+
+	if err := a.Save(); err != nil {
+		log.Fatalf("Failed to save archive: %v", err)
+	}
+	fmt.Printf("Imported into %s: %d added, %d replaced, %d unchanged.\n",
+		*dbPath, counts[archive.Added], counts[archive.Replaced], counts[archive.Unchanged])
+}
+
+// runQuery prints a report over the archive
+func runQuery(args []string) {
+	flags := flag.NewFlagSet("query", flag.ExitOnError)
+	dbPath := flags.String("db", defaultArchivePath, "Path to the archive")
+	format := flags.String("format", "text", "Output format: text, csv, tsv or json")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s query [flags] REPORT [TEXT]\n\nReports:\n", os.Args[0])
+		for _, r := range archive.Reports {
+			fmt.Fprintf(flags.Output(), "  %-22s %s\n", strings.TrimSpace(r.Name+" "+r.Arg), r.Description)
+		}
+		fmt.Fprintln(flags.Output(), "\nFlags:")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	report, ok := archive.ReportByName(flags.Arg(0))
+	if !ok {
+		log.Fatalf("Unknown report: %s", flags.Arg(0))
+	}
+	arg := strings.Join(flags.Args()[1:], " ")
+	if report.Arg != "" && arg == "" {
+		log.Fatalf("Report %s needs %s", report.Name, report.Arg)
+	}
+
+	a, err := archive.Open(*dbPath)
+	if err != nil {
+		log.Fatalf("Failed to open archive: %v", err)
+	}
+	content, err := formatTable(report.Run(a, arg), *format)
+	if err != nil {
+		log.Fatal(err)
+	}
+	os.Stdout.Write(content)
+}
+
+// formatTable writes a table in one of the query output formats
+func formatTable(t table.Table, format string) ([]byte, error) {
+	switch format {
+	case "text":
+		return t.FormatToText(), nil
+	case "csv":
+		return t.FormatToCSV(',')
+	case "tsv":
+		return t.FormatToCSV('\t')
+	case "json":
+		return t.FormatToJSON()
+	default:
+		return nil, fmt.Errorf("unknown output format: %s", format)
+	}
+}
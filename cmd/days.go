@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/toszr/dietician/meal"
+	"github.com/toszr/dietician/parser"
+)
+
+// dateLayout is the layout of dates in reports
+const dateLayout = "2006-01-02"
+
+// dayFileExts are the day file formats in order of preference when a day is saved in several of them
+var dayFileExts = []string{".json", ".xml", ".md"}
+
+// parseDays parses the day files found in paths, sorted by date
+func parseDays(paths []string) ([]meal.Plan, error) {
+	var plans []meal.Plan
+	for _, path := range paths {
+		files, err := dayFiles(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list day files in '%s': %w", path, err)
+		}
+		for _, file := range files {
+			mealPlan, err := parser.ParseFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse input file '%s': %w", file, err)
+			}
+			plans = append(plans, mealPlan)
+		}
+	}
+	sort.SliceStable(plans, func(i, j int) bool {
+		return plans[i].Date.Before(plans[j].Date)
+	})
+	return plans, nil
+}
+
+// dayFiles returns path itself if it is a file, or one file per day inside it if it is a directory,
+// choosing the format by dayFileExts when a day is saved in several formats
+func dayFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	byDay := map[string]string{}
+	rank := func(name string) int {
+		ext := strings.ToLower(filepath.Ext(name))
+		for i, e := range dayFileExts {
+			if e == ext {
+				return i
+			}
+		}
+		return -1
+	}
+	for _, entry := range entries {
+		r := rank(entry.Name())
+		if entry.IsDir() || r < 0 {
+			continue
+		}
+		day := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		if chosen, ok := byDay[day]; !ok || r < rank(chosen) {
+			byDay[day] = entry.Name()
+		}
+	}
+
+	files := make([]string, 0, len(byDay))
+	for _, name := range byDay {
+		files = append(files, filepath.Join(path, name))
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// dateRange returns the plans dated between from and to inclusive, given in dateLayout; an empty
+// bound is open. Plans without a date are kept only when both bounds are open.
+func dateRange(plans []meal.Plan, from, to string) ([]meal.Plan, error) {
+	if from == "" && to == "" {
+		return plans, nil
+	}
+	var start, end time.Time
+	var err error
+	if from != "" {
+		if start, err = time.Parse(dateLayout, from); err != nil {
+			return nil, fmt.Errorf("invalid start date %q, expected YYYY-MM-DD", from)
+		}
+	}
+	if to != "" {
+		if end, err = time.Parse(dateLayout, to); err != nil {
+			return nil, fmt.Errorf("invalid end date %q, expected YYYY-MM-DD", to)
+		}
+	}
+	var kept []meal.Plan
+	for _, p := range plans {
+		date, _ := time.Parse(dateLayout, p.Date.Format(dateLayout))
+		if p.Date.IsZero() || (!start.IsZero() && date.Before(start)) || (!end.IsZero() && date.After(end)) {
+			continue
+		}
+		kept = append(kept, p)
+	}
+	return kept, nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/toszr/dietician/meal"
+	"github.com/toszr/dietician/parser"
+)
+
+// runDiff compares two day files and exits with 0 if they are the same, 1 if they differ
+// and 2 on errors, like diff(1)
+func runDiff(args []string) {
+	flags := flag.NewFlagSet("diff", flag.ExitOnError)
+	format := flags.String("format", "text", "Output format: text, md or json")
+	quiet := flags.Bool("q", false, "Report only the exit status")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s diff [flags] OLD NEW\n\nFlags:\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() != 2 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	plans := make([]meal.Plan, 2)
+	for i, path := range flags.Args() {
+		mealPlan, err := parser.ParseFile(path)
+		if err != nil {
+			log.Printf("Failed to parse input file '%s': %v", path, err)
+			os.Exit(2)
+		}
+		plans[i] = mealPlan
+	}
+
+	diff := meal.DiffPlans(plans[0], plans[1])
+	if !*quiet {
+		content, err := formatDiff(diff, *format)
+		if err != nil {
+			log.Print(err)
+			os.Exit(2)
+		}
+		fmt.Print(content)
+	}
+	if !diff.IsEmpty() {
+		os.Exit(1)
+	}
+}
+
+// formatDiff renders the differences of plans in a report format
+func formatDiff(diff meal.PlanDiff, format string) (string, error) {
+	switch format {
+	case "text":
+		return diff.FormatToText(), nil
+	case "md":
+		return diff.FormatToMarkdown(), nil
+	case "json":
+		return diff.FormatToJSON()
+	default:
+		return "", fmt.Errorf("unknown output format: %s", format)
+	}
+}
@@ -0,0 +1,309 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/toszr/dietician/i18n"
+	"github.com/toszr/dietician/ical"
+	"github.com/toszr/dietician/layout"
+	"github.com/toszr/dietician/meal"
+	"github.com/toszr/dietician/pdf"
+	"github.com/toszr/dietician/table"
+	"github.com/toszr/dietician/vault"
+)
+
+// outputOptions select the output format and its settings
+type outputOptions struct {
+	Format     string
+	Table      string
+	MealTimes  string
+	Template   string
+	Lang       string
+	History    string
+	Processing bool
+	Diets      bool
+	// Only is a comma-separated list of diets all dishes must suit
+	Only string
+	// Tags is a comma-separated list of tags all dishes must have
+	Tags string
+	// Profile is set on the plans, its fields replacing those read from the files
+	Profile meal.Profile
+}
+
+// formatPlan renders the plan in the requested output format and returns the content with its file extension
+func formatPlan(mealPlan *meal.Plan, opts outputOptions) ([]byte, string, error) {
+	plans, err := preparePlans([]meal.Plan{*mealPlan}, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	mealPlan = &plans[0]
+
+	if opts.Template != "" {
+		return renderPlans(plans, opts)
+	}
+
+	switch opts.Format {
+	case "md":
+		plans, labels, err := localize([]meal.Plan{*mealPlan}, opts.Lang)
+		if err != nil {
+			return nil, "", err
+		}
+		return []byte(plans[0].FormatToMarkdownIn(labels)), ".md", nil
+	case "json":
+		// The JSON document is the canonical data and stays in the language of the menu
+		content, err := mealPlan.FormatToJSON()
+		return []byte(content), ".json", err
+	case "html":
+		plans, labels, err := localize([]meal.Plan{*mealPlan}, opts.Lang)
+		if err != nil {
+			return nil, "", err
+		}
+		content, err := plans[0].FormatToHTMLIn(labels)
+		return []byte(content), ".html", err
+	case "jsonld":
+		plans, labels, err := localize([]meal.Plan{*mealPlan}, opts.Lang)
+		if err != nil {
+			return nil, "", err
+		}
+		content, err := plans[0].FormatToJSONLDIn(labels)
+		return []byte(content), ".jsonld", err
+	case "obsidian":
+		plans, labels, err := localize([]meal.Plan{*mealPlan}, opts.Lang)
+		if err != nil {
+			return nil, "", err
+		}
+		return []byte(vault.Day(plans[0], labels)), ".md", nil
+	default:
+		return renderPlans(plans, opts)
+	}
+}
+
+// annotated reports whether dishes are annotated with their changes, processing or diets
+func (opts outputOptions) annotated() bool {
+	return opts.History != "" || opts.Processing || opts.Diets
+}
+
+// plainFormats are the output formats that do not show annotations
+var plainFormats = []string{"json", "jsonld", "obsidian", "pdf", "csv", "tsv", "xlsx", "ics"}
+
+// preparePlans keeps the dishes selected by the options and adds the annotations they request
+func preparePlans(plans []meal.Plan, opts outputOptions) ([]meal.Plan, error) {
+	if opts.annotated() && opts.Template == "" && slices.Contains(plainFormats, opts.Format) {
+		return nil, fmt.Errorf("output format %s does not show -history, -processing or -diets annotations", opts.Format)
+	}
+	if opts.Only != "" || opts.Tags != "" {
+		var err error
+		if plans, err = filterDishes(plans, opts); err != nil {
+			return nil, err
+		}
+	}
+	if !opts.annotated() {
+		return plans, nil
+	}
+
+	labels, err := i18n.LabelsFor(opts.Lang)
+	if err != nil {
+		return nil, err
+	}
+	if opts.History != "" {
+		if err := annotateChanges(plans, opts.History, labels); err != nil {
+			return nil, err
+		}
+	}
+	for i := range plans {
+		if opts.Processing {
+			annotateProcessing(&plans[i], labels)
+		}
+		if opts.Diets {
+			annotateDiets(&plans[i], labels)
+		}
+	}
+	return plans, nil
+}
+
+// annotateProcessing adds the mean processing group to the plan and the processing group
+// with the additives found to every dish
+func annotateProcessing(mealPlan *meal.Plan, labels meal.Labels) {
+	*mealPlan = mealPlan.Classified()
+	if len(mealPlan.Meals) > 0 {
+		mealPlan.Annotations = append(mealPlan.Annotations, labels.Processing+": "+strconv.FormatFloat(mealPlan.Processing(), 'f', 1, 64))
+	}
+	for i := range mealPlan.Meals {
+		for j := range mealPlan.Meals[i].Dishes {
+			dish := &mealPlan.Meals[i].Dishes[j]
+			note := labels.Processing + ": " + strconv.Itoa(dish.Processing())
+			if additives := dish.Additives(); len(additives) > 0 {
+				names := make([]string, 0, len(additives))
+				for _, a := range additives {
+					names = append(names, a.String())
+				}
+				note += "; " + labels.Additives + ": " + strings.Join(names, ", ")
+			}
+			dish.Annotations = append(dish.Annotations, note)
+		}
+	}
+}
+
+// annotateDiets adds the diets every dish suits
+func annotateDiets(mealPlan *meal.Plan, labels meal.Labels) {
+	for i := range mealPlan.Meals {
+		for j := range mealPlan.Meals[i].Dishes {
+			dish := &mealPlan.Meals[i].Dishes[j]
+			diets := dish.Diets()
+			if len(diets) == 0 {
+				continue
+			}
+			names := make([]string, 0, len(diets))
+			for _, diet := range diets {
+				names = append(names, labels.DietName(diet))
+			}
+			dish.Annotations = append(dish.Annotations, labels.Diets+": "+strings.Join(names, ", "))
+		}
+	}
+}
+
+// filterDishes keeps the dishes of the plans that suit all diets of opts.Only and have all tags of opts.Tags
+func filterDishes(plans []meal.Plan, opts outputOptions) ([]meal.Plan, error) {
+	diets := splitList(opts.Only)
+	for _, diet := range diets {
+		if _, ok := meal.Diets.Diet(diet); !ok {
+			return nil, fmt.Errorf("unknown diet: %s (supported: %s)", diet, strings.Join(meal.Diets.Names(), ", "))
+		}
+	}
+	tags := splitList(opts.Tags)
+	filtered := make([]meal.Plan, 0, len(plans))
+	for _, p := range plans {
+		filtered = append(filtered, p.Filter(func(d meal.Dish) bool {
+			return d.Suits(diets) && d.HasTags(tags)
+		}))
+	}
+	return filtered, nil
+}
+
+// splitList splits a comma-separated list, leaving out empty items
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// formatPlans renders plans of several days in one of the formats covering multiple days
+func formatPlans(plans []meal.Plan, opts outputOptions) ([]byte, string, error) {
+	plans, err := preparePlans(plans, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	return renderPlans(plans, opts)
+}
+
+// renderPlans renders prepared plans in one of the formats covering multiple days
+func renderPlans(plans []meal.Plan, opts outputOptions) ([]byte, string, error) {
+	original := plans
+	plans, labels, err := localize(plans, opts.Lang)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if opts.Template != "" {
+		t, err := layout.Load(opts.Template, labels)
+		if err != nil {
+			return nil, "", err
+		}
+		content, err := t.Execute(plans)
+		return content, t.Ext(), err
+	}
+
+	switch opts.Format {
+	case "pdf":
+		content, err := pdf.Render(plans, labels)
+		return content, ".pdf", err
+	case "csv", "tsv":
+		build, ok := table.ByName(opts.Table)
+		if !ok {
+			return nil, "", fmt.Errorf("unknown table: %s", opts.Table)
+		}
+		comma := ','
+		if opts.Format == "tsv" {
+			comma = '\t'
+		}
+		content, err := build(plans).FormatToCSV(comma)
+		return content, "." + opts.Format, err
+	case "xlsx":
+		build, ok := table.ByName(opts.Table)
+		if !ok {
+			return nil, "", fmt.Errorf("unknown table: %s", opts.Table)
+		}
+		content, err := table.FormatToXLSX(plans, build)
+		return content, ".xlsx", err
+	case "ics":
+		times, err := ical.ParseTimes(opts.MealTimes, ical.DefaultTimes)
+		if err != nil {
+			return nil, "", err
+		}
+		times = translateTimes(times, original, plans)
+		content, err := ical.Render(plans, ical.Options{Times: times, Labels: labels, Stamp: time.Now()})
+		return content, ".ics", err
+	case "md", "json", "jsonld", "html", "obsidian":
+		return nil, "", fmt.Errorf("output format %s covers a single day, convert the files one by one", opts.Format)
+	default:
+		return nil, "", fmt.Errorf("unknown output format: %s", opts.Format)
+	}
+}
+
+// translateTimes returns the meal times with those of the meals of the original plans added
+// under the names the meals have in the translated plans
+func translateTimes(times ical.Times, original, translated []meal.Plan) ical.Times {
+	result := ical.Times{}
+	for name, offset := range times {
+		result[name] = offset
+	}
+	for i := range original {
+		for j, m := range original[i].Meals {
+			name := translated[i].Meals[j].Name
+			if offset, ok := times[m.Name]; ok && name != m.Name {
+				if _, set := times[name]; !set {
+					result[name] = offset
+				}
+			}
+		}
+	}
+	return result
+}
+
+// localize returns the labels of the language and the plans with meal and ingredient names
+// translated into it, logging the names missing from the dictionary
+func localize(plans []meal.Plan, lang string) ([]meal.Plan, meal.Labels, error) {
+	if lang == "" {
+		lang = i18n.DefaultLanguage
+	}
+	labels, err := i18n.LabelsFor(lang)
+	if err != nil || lang == i18n.DefaultLanguage {
+		return plans, labels, err
+	}
+
+	translator, err := i18n.NewTranslator(lang)
+	if err != nil {
+		return nil, meal.Labels{}, err
+	}
+	// Allergens and diets are found by the Polish names, so the plans are classified before translation
+	translated := make([]meal.Plan, 0, len(plans))
+	for _, p := range plans {
+		translated = append(translated, translator.Plan(p.Classified()))
+	}
+	if terms := translator.Untranslated(); len(terms) > 0 {
+		log.Printf("%d name(s) without %s translation:", len(terms), lang)
+		for _, term := range terms {
+			log.Printf("  %s (%d)", term.Name, term.Count)
+		}
+	}
+	return translated, labels, nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/toszr/dietician/meal"
+	"github.com/toszr/dietician/parser"
+)
+
+func samplePlans(t *testing.T, names ...string) []meal.Plan {
+	t.Helper()
+	var plans []meal.Plan
+	for _, name := range names {
+		plan, err := parser.ParseFile("../samples/" + name)
+		require.NoError(t, err)
+		plans = append(plans, plan)
+	}
+	return plans
+}
+
+func TestFormatPlans(t *testing.T) {
+	t.Run("calendar in english", func(t *testing.T) {
+		content, ext, err := formatPlans(samplePlans(t, "010126.json"), outputOptions{Format: "ics", Lang: "en"})
+
+		require.NoError(t, err)
+		assert.Equal(t, ".ics", ext)
+		assert.Contains(t, string(content), "SUMMARY:Breakfast")
+		assert.Contains(t, string(content), "DTSTART:20260101T073000")
+	})
+
+	t.Run("calendar with translated meal times", func(t *testing.T) {
+		content, _, err := formatPlans(samplePlans(t, "010126.json"), outputOptions{Format: "ics", Lang: "en", MealTimes: "Śniadanie=8:15"})
+
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "DTSTART:20260101T081500")
+	})
+}
+
+func TestLocalizedClassification(t *testing.T) {
+	dietColumn := func(t *testing.T, lang string) []string {
+		content, _, err := formatPlans(samplePlans(t, "010126.json", "020126.json"), outputOptions{Format: "csv", Table: "dishes", Lang: lang})
+		require.NoError(t, err)
+		records, err := csv.NewReader(bytes.NewReader(content)).ReadAll()
+		require.NoError(t, err)
+		var diets []string
+		for _, record := range records[1:] {
+			diets = append(diets, record[5])
+		}
+		return diets
+	}
+
+	t.Run("table diets", func(t *testing.T) {
+		polish := dietColumn(t, "pl")
+
+		assert.Contains(t, polish, "")
+		assert.Equal(t, polish, dietColumn(t, "en"))
+		assert.Equal(t, polish, dietColumn(t, "de"))
+	})
+
+	t.Run("json-ld diets", func(t *testing.T) {
+		plan := samplePlans(t, "010126.json")[0]
+		polish, _, err := formatPlan(&plan, outputOptions{Format: "jsonld", Lang: "pl"})
+		require.NoError(t, err)
+		english, _, err := formatPlan(&plan, outputOptions{Format: "jsonld", Lang: "en"})
+		require.NoError(t, err)
+
+		assert.Equal(t, bytes.Count(polish, []byte("suitableForDiet")), bytes.Count(english, []byte("suitableForDiet")))
+	})
+}
+
+func TestLocalizedVault(t *testing.T) {
+	frontMatterAllergens := func(t *testing.T, lang string) string {
+		plan := samplePlans(t, "010126.json")[0]
+		content, _, err := formatPlan(&plan, outputOptions{Format: "obsidian", Lang: lang})
+		require.NoError(t, err)
+		_, rest, _ := strings.Cut(string(content), "allergens:")
+		allergens, _, _ := strings.Cut(rest, "---")
+		return allergens
+	}
+
+	polish := frontMatterAllergens(t, "pl")
+	english := frontMatterAllergens(t, "en")
+
+	assert.Contains(t, polish, "mleko")
+	assert.Contains(t, english, "milk")
+	assert.NotContains(t, english, "mleko")
+	assert.Equal(t, strings.Count(polish, "\n"), strings.Count(english, "\n"))
+}
+
+func TestAnnotations(t *testing.T) {
+	t.Run("template of several days", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "notes.md.tmpl")
+		require.NoError(t, os.WriteFile(path, []byte("{{range .Annotations}}{{.}}\n{{end}}"), 0644))
+
+		content, _, err := formatPlans(samplePlans(t, "010126.json", "020126.json"), outputOptions{Template: path, Lang: "pl", Processing: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, strings.Count(string(content), "Przetworzenie (NOVA): "))
+	})
+
+	t.Run("format without annotations", func(t *testing.T) {
+		_, _, err := formatPlans(samplePlans(t, "010126.json"), outputOptions{Format: "pdf", Lang: "pl", Diets: true})
+
+		assert.EqualError(t, err, "output format pdf does not show -history, -processing or -diets annotations")
+	})
+}
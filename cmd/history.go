@@ -0,0 +1,92 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/toszr/dietician/archive"
+	"github.com/toszr/dietician/meal"
+	"github.com/toszr/dietician/rotation"
+	"github.com/toszr/dietician/search"
+	"github.com/toszr/dietician/table"
+)
+
+// runDishHistory lists every serving of the dishes whose names contain the query,
+// with the changes of ingredients since the previous serving
+func runDishHistory(args []string) {
+	flags := flag.NewFlagSet("dish-history", flag.ExitOnError)
+	dbPath := flags.String("db", defaultArchivePath, "Path to the archive")
+	in := flags.String("in", "", "Day file or directory of day files to use instead of the archive")
+	format := flags.String("format", "text", "Output format: text, csv, tsv or json")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s dish-history [flags] DISH\n\nFlags:\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	query := search.Fold(strings.Join(flags.Args(), " "))
+
+	plans, err := loadPlans(*dbPath, *in)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	t := table.Table{Header: []string{"dish", "date", "meal", "changes"}}
+	for _, g := range rotation.Match(plans) {
+		if !strings.Contains(search.Fold(g.Name()), query) {
+			continue
+		}
+		for i, s := range g.Servings {
+			changes := ""
+			if i > 0 {
+				changes = meal.DiffDishes(g.Servings[i-1].Dish, s.Dish).String()
+			}
+			t.Rows = append(t.Rows, []string{s.Dish.Name, s.Date.Format(dateLayout), s.Meal, changes})
+		}
+	}
+	if len(t.Rows) == 0 {
+		log.Fatalf("No dishes matching %q", strings.Join(flags.Args(), " "))
+	}
+
+	content, err := formatTable(t, *format)
+	if err != nil {
+		log.Fatal(err)
+	}
+	os.Stdout.Write(content)
+}
+
+// annotateChanges adds to every dish of the plans the changes of ingredients since it was last served in the archive
+func annotateChanges(plans []meal.Plan, dbPath string, labels meal.Labels) error {
+	a, err := archive.Open(dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	history := rotation.NewHistory(a.Plans())
+	for i := range plans {
+		annotatePlanChanges(&plans[i], history, labels)
+	}
+	return nil
+}
+
+// annotatePlanChanges adds to every dish of the plan the changes of ingredients since it was last served
+func annotatePlanChanges(mealPlan *meal.Plan, history *rotation.History, labels meal.Labels) {
+	for i := range mealPlan.Meals {
+		for j := range mealPlan.Meals[i].Dishes {
+			dish := &mealPlan.Meals[i].Dishes[j]
+			previous, ok := history.Previous(mealPlan.Date, *dish)
+			if !ok {
+				continue
+			}
+			if diff := meal.DiffDishes(previous.Dish, *dish); !diff.IsEmpty() {
+				dish.Annotations = append(dish.Annotations, labels.Changes+" "+labels.FormatDate(previous.Date)+": "+diff.String())
+			}
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/toszr/dietician/meal"
+)
+
+// runHousehold merges the plans of several people for one day into a household menu showing who eats what,
+// with a shopping list and the allergens of the day. Arguments are day files or directories, each optionally
+// prefixed with the person it is for, e.g. "Anna=010126-anna.json"; without arguments the archive is used.
+func runHousehold(args []string) {
+	flags := flag.NewFlagSet("household", flag.ExitOnError)
+	dbPath := flags.String("db", defaultArchivePath, "Path to the archive")
+	date := flags.String("date", "", "Day to merge, YYYY-MM-DD; required unless all plans are for the same day")
+	format := flags.String("format", "md", "Output format: md or html")
+	lang := flags.String("lang", "pl", "Language of the output labels and of meal and ingredient names")
+	outputPath := flags.String("output", "", "Path to the output file, standard output by default")
+	flags.Parse(args)
+
+	plans, err := householdPlans(*dbPath, flags.Args())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *date != "" {
+		if plans, err = dateRange(plans, *date, *date); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if len(plans) == 0 {
+		log.Fatal("No plans to merge")
+	}
+	content, err := formatHousehold(plans, *format, *lang)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *outputPath == "" {
+		fmt.Print(content)
+		return
+	}
+	if err := os.WriteFile(*outputPath, []byte(content), 0644); err != nil {
+		log.Fatalf("Failed to write output file: %v", err)
+	}
+	fmt.Printf("Merged %d plan(s) into %s\n", len(plans), *outputPath)
+}
+
+// formatHousehold merges the plans into a household menu in the output format and language. The plans
+// are classified by localize before they are translated, so the allergens are found by the Polish names.
+func formatHousehold(plans []meal.Plan, format, lang string) (string, error) {
+	plans, labels, err := localize(plans, lang)
+	if err != nil {
+		return "", err
+	}
+	household, err := meal.NewHousehold(plans)
+	if err != nil {
+		return "", fmt.Errorf("failed to merge plans: %w", err)
+	}
+
+	switch format {
+	case "md":
+		return household.FormatToMarkdownIn(labels), nil
+	case "html":
+		return household.FormatToHTMLIn(labels)
+	default:
+		return "", fmt.Errorf("unknown output format: %s", format)
+	}
+}
+
+// householdPlans parses the day files of the arguments, setting the person of those prefixed with one,
+// or returns the plans of the archive without arguments. The text before "=" is a person only when it
+// has no path separator, so paths of directories with "=" in their names are read whole.
+func householdPlans(dbPath string, args []string) ([]meal.Plan, error) {
+	if len(args) == 0 {
+		return loadPlans(dbPath, "")
+	}
+	var plans []meal.Plan
+	for _, arg := range args {
+		person, path, named := strings.Cut(arg, "=")
+		if named && strings.ContainsAny(person, "/"+string(filepath.Separator)) {
+			named = false
+		}
+		if !named {
+			path = arg
+		}
+		parsed, err := parseDays([]string{path})
+		if err != nil {
+			return nil, err
+		}
+		if named {
+			for i := range parsed {
+				parsed[i].Profile.Person = person
+			}
+		}
+		plans = append(plans, parsed...)
+	}
+	return plans, nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatHousehold(t *testing.T) {
+	plans := samplePlans(t, "010126.json", "010126.json")
+	plans[0].Profile.Variant = "wege"
+	plans[1].Profile.Variant = "sport"
+
+	allergens := func(t *testing.T, lang, title string) string {
+		content, err := formatHousehold(plans, "md", lang)
+		require.NoError(t, err)
+		_, summary, _ := strings.Cut(content, "# "+title+"\n")
+		return summary
+	}
+
+	polish := allergens(t, "pl", "Alergeny")
+	english := allergens(t, "en", "Allergens")
+
+	assert.Contains(t, polish, "- mleko: wege, sport\n")
+	assert.Contains(t, english, "- milk: wege, sport\n")
+	assert.Equal(t, strings.Count(polish, "\n- "), strings.Count(english, "\n- "))
+}
+
+func TestHouseholdPlans(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "menu=styczeń")
+	require.NoError(t, os.Mkdir(dir, 0755))
+	data, err := os.ReadFile(filepath.Join("..", "samples", "010126.json"))
+	require.NoError(t, err)
+	path := filepath.Join(dir, "010126.json")
+	require.NoError(t, os.WriteFile(path, data, 0644))
+
+	t.Run("person prefix", func(t *testing.T) {
+		plans, err := householdPlans("", []string{"Anna=" + path})
+
+		require.NoError(t, err)
+		require.Len(t, plans, 1)
+		assert.Equal(t, "Anna", plans[0].Profile.Person)
+	})
+
+	t.Run("path with an equals sign", func(t *testing.T) {
+		plans, err := householdPlans("", []string{path})
+
+		require.NoError(t, err)
+		require.Len(t, plans, 1)
+		assert.Empty(t, plans[0].Profile.Person)
+	})
+}
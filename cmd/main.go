@@ -1,25 +1,79 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
+	"github.com/toszr/dietician/i18n"
+	"github.com/toszr/dietician/meal"
 	"github.com/toszr/dietician/parser"
 )
 
+// commands are the subcommands selected by the first argument, the default being conversion of day files
+var commands = map[string]func(args []string){
+	"archive":      runArchive,
+	"diff":         runDiff,
+	"dish-history": runDishHistory,
+	"household":    runHousehold,
+	"migrate":      runMigrate,
+	"plants":       runPlants,
+	"query":        runQuery,
+	"recipes":      runRecipes,
+	"rotation":     runRotation,
+	"search":       runSearch,
+	"stats":        runStats,
+	"vault":        runVault,
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			command(os.Args[2:])
+			return
+		}
+	}
+
 	var (
-		inputPath  = flag.String("input", "", "Path to the input file (XML or JSON)")
-		outputPath = flag.String("output", "", "Path to the output file (Markdown)")
+		inputPath  = flag.String("input", "", "Path to the input file (XML, JSON or Markdown)")
+		outputPath = flag.String("output", "", "Path to the output file")
+		format     = flag.String("format", "md", "Output format: md, json, jsonld, html, obsidian, pdf, csv, tsv, xlsx or ics")
+		tableName  = flag.String("table", "dishes", "Table written by the csv, tsv and xlsx formats: dishes or ingredients")
+		mealTimes  = flag.String("meal-times", "", "Times of meals in the ics format, e.g. \"Śniadanie=7:00,Obiad=14:30\"")
+		tmpl       = flag.String("template", "", "Go template file, or the name of a bundled template, used instead of --format")
+		lang       = flag.String("lang", "pl", "Language of the output labels and of meal and ingredient names: "+strings.Join(i18n.Languages(), ", "))
+		history    = flag.String("history", "", "Archive used to annotate dishes with ingredient changes since they were last served, in md, html and template output")
+		processing = flag.Bool("processing", false, "Annotate the day and its dishes with the NOVA-style processing group and the additives found, in md, html and template output")
+		diets      = flag.Bool("diets", false, "Annotate dishes with the diets they suit, in md, html and template output")
+		only       = flag.String("only", "", "Keep only the dishes suiting all of a comma-separated list of diets: "+strings.Join(meal.Diets.Names(), ", "))
+		tags       = flag.String("tags", "", "Keep only the dishes with all of a comma-separated list of the provider's tags, e.g. \"wege,nowość\"")
+		person     = flag.String("person", "", "Person the plans are for, used in headers and output file names")
+		variant    = flag.String("variant", "", "Diet variant of the plans, e.g. \"wege\" or \"wege 1500 kcal\"")
+		calories   = flag.Int("calories", 0, "Calorie target of the diet variant in kcal, e.g. 1500")
+		schema     = flag.Bool("schema", false, "Write the JSON Schema of the json output format and exit")
 	)
 	flag.Parse()
 
+	if *schema {
+		writeSchema(*outputPath)
+		return
+	}
+
+	profile := meal.Profile{Person: *person}
+	profile.Variant, profile.Calories = meal.ParseVariant(*variant)
+	if *calories != 0 {
+		profile.Calories = *calories
+	}
+	opts := outputOptions{Format: *format, Table: *tableName, MealTimes: *mealTimes, Template: *tmpl, Lang: *lang, History: *history, Processing: *processing, Diets: *diets, Only: *only, Tags: *tags, Profile: profile}
 	if *inputPath != "" {
-		processFile(*inputPath, *outputPath)
+		processFile(*inputPath, *outputPath, opts)
+	} else if flag.NArg() > 0 {
+		processFiles(flag.Args(), *outputPath, opts)
 	} else {
 		if *outputPath != "" {
 			log.Println("Warning: --output flag is ignored when --input is not provided.")
@@ -28,30 +82,27 @@ func main() {
 	}
 }
 
-func processFile(inputPath, outputPath string) {
-	data, err := os.ReadFile(inputPath)
-	if err != nil {
-		log.Fatalf("Failed to read input file: %v", err)
-	}
-
-	var markdownContent string
-	ext := strings.ToLower(filepath.Ext(inputPath))
-	switch ext {
-	case ".xml":
-		markdownContent, err = parser.ParseXMLToMarkdown(data)
-	case ".json":
-		markdownContent, err = parser.ParseJSONToMarkdown(data)
-	default:
-		log.Printf("Unsupported file type: %s, skipping", ext)
+func processFile(inputPath, outputPath string, opts outputOptions) {
+	mealPlan, err := parser.ParseFile(inputPath)
+	if errors.Is(err, parser.ErrUnsupportedFormat) {
+		log.Printf("Unsupported file type: %s, skipping", filepath.Ext(inputPath))
 		return
 	}
-
 	if err != nil {
 		log.Fatalf("Failed to parse input file '%s': %v", inputPath, err)
 	}
+	mealPlan.Profile = mealPlan.Profile.Merge(opts.Profile)
 
-	outputFilePath := parser.GetOutputPath(inputPath, outputPath)
-	err = os.WriteFile(outputFilePath, []byte(markdownContent), 0644)
+	content, ext, err := formatPlan(&mealPlan, opts)
+	if err != nil {
+		log.Fatalf("Failed to format '%s': %v", inputPath, err)
+	}
+
+	outputFilePath := parser.GetPlanOutputPath(inputPath, outputPath, mealPlan, ext)
+	if filepath.Clean(outputFilePath) == filepath.Clean(inputPath) {
+		log.Fatalf("Refusing to overwrite input file '%s', use --output", inputPath)
+	}
+	err = os.WriteFile(outputFilePath, content, 0644)
 	if err != nil {
 		log.Fatalf("Failed to write output file: %v", err)
 	}
@@ -81,7 +132,7 @@ func processSamplesDir(inputDir string) {
 			if !mdFiles[base] {
 				inputPath := filepath.Join("samples", file.Name())
 				fmt.Printf("Processing %s...\n", inputPath)
-				processFile(inputPath, "")
+				processFile(inputPath, "", outputOptions{Format: "md"})
 				processedCount++
 			}
 		}
@@ -93,3 +144,47 @@ func processSamplesDir(inputDir string) {
 		fmt.Printf("Finished processing. %d new markdown file(s) created.\n", processedCount)
 	}
 }
+
+// processFiles renders several day files into a single output in a format covering multiple days
+func processFiles(inputPaths []string, outputPath string, opts outputOptions) {
+	if outputPath == "" {
+		log.Fatalf("--output is required when converting several files")
+	}
+
+	plans := make([]meal.Plan, 0, len(inputPaths))
+	for _, inputPath := range inputPaths {
+		mealPlan, err := parser.ParseFile(inputPath)
+		if err != nil {
+			log.Fatalf("Failed to parse input file '%s': %v", inputPath, err)
+		}
+		mealPlan.Profile = mealPlan.Profile.Merge(opts.Profile)
+		plans = append(plans, mealPlan)
+	}
+	sort.SliceStable(plans, func(i, j int) bool {
+		return plans[i].Date.Before(plans[j].Date)
+	})
+
+	content, _, err := formatPlans(plans, opts)
+	if err != nil {
+		log.Fatalf("Failed to format: %v", err)
+	}
+	if err := os.WriteFile(outputPath, content, 0644); err != nil {
+		log.Fatalf("Failed to write output file: %v", err)
+	}
+
+	fmt.Printf("Successfully converted %d file(s) to %s\n", len(plans), outputPath)
+}
+
+func writeSchema(outputPath string) {
+	schema, err := meal.JSONSchema()
+	if err != nil {
+		log.Fatalf("Failed to generate JSON Schema: %v", err)
+	}
+	if outputPath == "" {
+		fmt.Print(schema)
+		return
+	}
+	if err := os.WriteFile(outputPath, []byte(schema), 0644); err != nil {
+		log.Fatalf("Failed to write output file: %v", err)
+	}
+}
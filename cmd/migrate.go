@@ -0,0 +1,131 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/toszr/dietician/meal"
+	"github.com/toszr/dietician/parser"
+)
+
+// backupExt is appended to the name of a file before it is rewritten by migrate
+const backupExt = ".bak"
+
+// runMigrate rewrites JSON day files written in an older schema version as current canonical documents.
+// Arguments are files or directories, samples/ by default.
+func runMigrate(args []string) {
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "Only report the files that would be migrated")
+	flags.Parse(args)
+
+	paths := flags.Args()
+	if len(paths) == 0 {
+		paths = []string{"samples"}
+	}
+
+	migratedCount := 0
+	for _, path := range paths {
+		files, err := jsonFiles(path)
+		if err != nil {
+			log.Fatalf("Failed to list JSON files in '%s': %v", path, err)
+		}
+		for _, file := range files {
+			migrated, err := migrateFile(file, *dryRun)
+			if err != nil {
+				log.Printf("Failed to migrate '%s': %v", file, err)
+				continue
+			}
+			if migrated {
+				migratedCount++
+			}
+		}
+	}
+
+	if *dryRun {
+		fmt.Printf("%d file(s) would be migrated.\n", migratedCount)
+	} else {
+		fmt.Printf("Finished migrating. %d file(s) rewritten.\n", migratedCount)
+	}
+}
+
+// jsonFiles returns path itself if it is a file, or the JSON files directly inside it if it is a directory
+func jsonFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.ToLower(filepath.Ext(entry.Name())) == ".json" {
+			files = append(files, filepath.Join(path, entry.Name()))
+		}
+	}
+	return files, nil
+}
+
+// migrateFile rewrites a single file in the current schema version, keeping a backup of the original.
+// It reports whether the file needed migrating.
+func migrateFile(path string, dryRun bool) (bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	doc, version, err := meal.Migrate(data)
+	if err != nil {
+		return false, err
+	}
+	if version == meal.SchemaVersion {
+		return false, nil
+	}
+	if dryRun {
+		fmt.Printf("Would migrate %s from schema version %d\n", path, version)
+		return true, nil
+	}
+
+	mealPlan, err := doc.ToPlan()
+	if err != nil {
+		return false, err
+	}
+	if date, ok := parser.DateFromPath(path); ok && mealPlan.Date.IsZero() {
+		mealPlan.Date = date
+	}
+	content, err := mealPlan.FormatToJSON()
+	if err != nil {
+		return false, err
+	}
+
+	if err := writeBackup(path+backupExt, data); err != nil {
+		return false, err
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return false, err
+	}
+
+	fmt.Printf("Migrated %s from schema version %d (backup in %s)\n", path, version, path+backupExt)
+	return true, nil
+}
+
+// writeBackup writes data to a new file, refusing to replace an earlier backup
+func writeBackup(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/toszr/dietician/meal"
+	"github.com/toszr/dietician/plants"
+)
+
+// runPlants counts the different plants eaten every week, towards the goal of 30 plants a week
+func runPlants(args []string) {
+	flags := flag.NewFlagSet("plants", flag.ExitOnError)
+	dbPath := flags.String("db", defaultArchivePath, "Path to the archive")
+	in := flags.String("in", "", "Day file or directory of day files to analyse instead of the archive")
+	from := flags.String("from", "", "First day of the range, YYYY-MM-DD")
+	to := flags.String("to", "", "Last day of the range, YYYY-MM-DD")
+	dishes := flags.String("dishes", "", "File with the names of the chosen dishes, one per line; all dishes count if not set")
+	minDaily := flags.Int("min-daily", 10, "Number of plants below which a day is marked as low in diversity")
+	format := flags.String("format", "md", "Output format: md or json")
+	flags.Parse(args)
+
+	plans, err := loadPlans(*dbPath, *in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if plans, err = dateRange(plans, *from, *to); err != nil {
+		log.Fatal(err)
+	}
+	if *dishes != "" {
+		chosen, err := readDishNames(*dishes)
+		if err != nil {
+			log.Fatalf("Failed to read chosen dishes: %v", err)
+		}
+		plans = chosenDishes(plans, chosen)
+	}
+	report := plants.Default.Weekly(plans, *minDaily)
+
+	switch *format {
+	case "md":
+		fmt.Print(report.FormatToMarkdown(plants.Default))
+	case "json":
+		content, err := report.FormatToJSON()
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Print(content)
+	default:
+		log.Fatalf("unknown output format: %s", *format)
+	}
+}
+
+// readDishNames reads the canonical names of dishes listed one per line, skipping empty lines and "#" comments
+func readDishNames(path string) (map[string]bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	names := map[string]bool{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			names[meal.CanonicalName(line)] = true
+		}
+	}
+	return names, scanner.Err()
+}
+
+// chosenDishes returns copies of the plans with only the dishes whose canonical names are chosen
+func chosenDishes(plans []meal.Plan, chosen map[string]bool) []meal.Plan {
+	kept := make([]meal.Plan, 0, len(plans))
+	for _, p := range plans {
+		plan := meal.Plan{Date: p.Date, Profile: p.Profile}
+		for _, m := range p.Meals {
+			var dishes []meal.Dish
+			for _, d := range m.Dishes {
+				if chosen[meal.CanonicalName(d.Name)] {
+					dishes = append(dishes, d)
+				}
+			}
+			if len(dishes) > 0 {
+				plan.Meals = append(plan.Meals, meal.Meal{Name: m.Name, Dishes: dishes})
+			}
+		}
+		kept = append(kept, plan)
+	}
+	return kept
+}
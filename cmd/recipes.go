@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/toszr/dietician/recipe"
+)
+
+// recipeFormats are the recipe manager formats with their writers and file extensions
+var recipeFormats = map[string]struct {
+	format func([]recipe.Entry) ([]byte, error)
+	ext    string
+}{
+	"mealie":  {recipe.FormatToMealie, ".zip"},
+	"paprika": {recipe.FormatToPaprika, ".paprikarecipes"},
+	"tandoor": {recipe.FormatToTandoor, ".zip"},
+}
+
+// runRecipes exports dishes to a recipe manager. Arguments are files or directories, samples/ by default.
+func runRecipes(args []string) {
+	flags := flag.NewFlagSet("recipes", flag.ExitOnError)
+	format := flags.String("format", "mealie", "Recipe manager format: mealie, paprika or tandoor")
+	dish := flags.String("dish", "", "Export only dishes whose names contain this text")
+	outputPath := flags.String("output", "", "Path to the output file, recipes with the format's extension by default")
+	flags.Parse(args)
+
+	recipeFormat, ok := recipeFormats[*format]
+	if !ok {
+		log.Fatalf("Unknown recipe format: %s", *format)
+	}
+	paths := flags.Args()
+	if len(paths) == 0 {
+		paths = []string{"samples"}
+	}
+
+	plans, err := parseDays(paths)
+	if err != nil {
+		log.Fatal(err)
+	}
+	entries := recipe.Entries(plans, *dish)
+	if len(entries) == 0 {
+		log.Fatalf("No dishes matching %q", *dish)
+	}
+	content, err := recipeFormat.format(entries)
+	if err != nil {
+		log.Fatalf("Failed to export recipes: %v", err)
+	}
+
+	if *outputPath == "" {
+		*outputPath = "recipes" + recipeFormat.ext
+	}
+	if err := os.WriteFile(*outputPath, content, 0644); err != nil {
+		log.Fatalf("Failed to write output file: %v", err)
+	}
+	fmt.Printf("Exported %d recipe(s) to %s\n", len(entries), *outputPath)
+}
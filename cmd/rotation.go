@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/toszr/dietician/archive"
+	"github.com/toszr/dietician/meal"
+	"github.com/toszr/dietician/rotation"
+	"github.com/toszr/dietician/table"
+)
+
+// runRotation reports the dishes that recur in the archive, their intervals and the rotation period of the menu
+func runRotation(args []string) {
+	flags := flag.NewFlagSet("rotation", flag.ExitOnError)
+	dbPath := flags.String("db", defaultArchivePath, "Path to the archive")
+	in := flags.String("in", "", "Day file or directory of day files to analyse instead of the archive")
+	format := flags.String("format", "text", "Output format: text, csv, tsv or json")
+	flags.Parse(args)
+
+	plans, err := loadPlans(*dbPath, *in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	report := rotation.Analyze(plans)
+
+	t := table.Table{Header: []string{"dish", "servings", "first", "last", "intervals", "next", "changes"}}
+	for _, g := range report.Groups {
+		intervals := make([]string, 0, len(g.Intervals))
+		for _, i := range g.Intervals {
+			intervals = append(intervals, strconv.Itoa(i))
+		}
+		t.Rows = append(t.Rows, []string{
+			g.Name(),
+			strconv.Itoa(len(g.Servings)),
+			g.Servings[0].Date.Format(dateLayout),
+			g.Last().Date.Format(dateLayout),
+			strings.Join(intervals, ";"),
+			g.Next(report.Period).Format(dateLayout),
+			strconv.Itoa(g.Changes),
+		})
+	}
+
+	content, err := formatTable(t, *format)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *format == "text" {
+		fmt.Printf("Rotation period: %d days (%.0f%% of recurrences)\n\n", report.Period, report.Support*100)
+	}
+	os.Stdout.Write(content)
+}
+
+// loadPlans reads the plans from day files if in is set, or from the archive otherwise
+func loadPlans(dbPath, in string) ([]meal.Plan, error) {
+	if in != "" {
+		return parseDays([]string{in})
+	}
+	a, err := archive.Open(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	return a.Plans(), nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/toszr/dietician/archive"
+	"github.com/toszr/dietician/meal"
+	"github.com/toszr/dietician/search"
+	"github.com/toszr/dietician/table"
+)
+
+// runSearch lists the dishes matching a query, e.g. "meal:obiad ingredient:tofu", the most recent first
+func runSearch(args []string) {
+	flags := flag.NewFlagSet("search", flag.ExitOnError)
+	in := flags.String("in", "samples", "Day file or directory of day files to search")
+	dbPath := flags.String("db", "", "Search the archive instead of day files")
+	format := flags.String("format", "text", "Output format: text, csv, tsv or json")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s search [flags] QUERY\n\n", os.Args[0])
+		fmt.Fprintf(flags.Output(), "Words match dish names and ingredients; meal:, dish: and ingredient: restrict a word to one field.\n\nFlags:\n")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	var plans []meal.Plan
+	if *dbPath != "" {
+		a, err := archive.Open(*dbPath)
+		if err != nil {
+			log.Fatalf("Failed to open archive: %v", err)
+		}
+		plans = a.Plans()
+	} else {
+		var err error
+		plans, err = parseDays([]string{*in})
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	results, err := search.NewIndex(plans).Search(strings.Join(flags.Args(), " "))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	t := table.Table{Header: []string{"date", "profile", "meal", "dish"}}
+	for _, r := range results {
+		date := ""
+		if !r.Date.IsZero() {
+			date = r.Date.Format(dateLayout)
+		}
+		t.Rows = append(t.Rows, []string{date, r.Profile.String(), r.Meal, r.Dish})
+	}
+	content, err := formatTable(t, *format)
+	if err != nil {
+		log.Fatal(err)
+	}
+	os.Stdout.Write(content)
+}
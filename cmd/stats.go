@@ -0,0 +1,46 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/toszr/dietician/stats"
+)
+
+// runStats summarises the dishes and ingredients of the days in a date range
+func runStats(args []string) {
+	flags := flag.NewFlagSet("stats", flag.ExitOnError)
+	dbPath := flags.String("db", defaultArchivePath, "Path to the archive")
+	in := flags.String("in", "", "Day file or directory of day files to summarise instead of the archive")
+	from := flags.String("from", "", "First day of the range, YYYY-MM-DD")
+	to := flags.String("to", "", "Last day of the range, YYYY-MM-DD")
+	top := flags.Int("top", 10, "Number of ingredients and dishes listed in the rankings")
+	format := flags.String("format", "md", "Output format: md or json")
+	flags.Parse(args)
+	if *top < 0 {
+		log.Fatalf("invalid -top %d, expected a number of at least 0", *top)
+	}
+
+	plans, err := loadPlans(*dbPath, *in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if plans, err = dateRange(plans, *from, *to); err != nil {
+		log.Fatal(err)
+	}
+	report := stats.Compute(plans, *top)
+
+	switch *format {
+	case "md":
+		fmt.Print(report.FormatToMarkdown())
+	case "json":
+		content, err := report.FormatToJSON()
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Print(content)
+	default:
+		log.Fatalf("unknown output format: %s", *format)
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/toszr/dietician/i18n"
+	"github.com/toszr/dietician/vault"
+)
+
+// runVault writes day notes and per-dish and per-ingredient notes for an Obsidian or Logseq vault.
+// Arguments are files or directories, samples/ by default.
+func runVault(args []string) {
+	flags := flag.NewFlagSet("vault", flag.ExitOnError)
+	outputDir := flags.String("output", "vault", "Directory of the vault")
+	lang := flags.String("lang", i18n.DefaultLanguage, "Language of the output labels and of meal and ingredient names: "+strings.Join(i18n.Languages(), ", "))
+	flags.Parse(args)
+
+	paths := flags.Args()
+	if len(paths) == 0 {
+		paths = []string{"samples"}
+	}
+
+	plans, err := parseDays(paths)
+	if err != nil {
+		log.Fatal(err)
+	}
+	plans, labels, err := localize(plans, *lang)
+	if err != nil {
+		log.Fatal(err)
+	}
+	notes, err := vault.Notes(plans, labels)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for name, content := range notes {
+		path := filepath.Join(*outputDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			log.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			log.Fatalf("Failed to write note: %v", err)
+		}
+	}
+
+	fmt.Printf("Wrote %d note(s) for %d day(s) to %s\n", len(notes), len(plans), *outputDir)
+}
@@ -3,6 +3,7 @@ module github.com/toszr/dietician
 go 1.25.1
 
 require (
+	github.com/go-pdf/fpdf v0.9.0
 	github.com/stretchr/testify v1.11.1
 	golang.org/x/text v0.29.0
 )
@@ -0,0 +1,185 @@
+// Package i18n provides output labels in other languages and translates canonical ingredient
+// and meal names with offline dictionaries.
+package i18n
+
+import (
+	"bufio"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/toszr/dietician/meal"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+//go:embed catalogs dictionaries
+var files embed.FS
+
+// DefaultLanguage is the language of the menus, which needs no translation
+const DefaultLanguage = "pl"
+
+// Languages returns the codes of all supported output languages
+func Languages() []string {
+	langs := []string{DefaultLanguage}
+	entries, _ := fs.ReadDir(files, "catalogs")
+	for _, e := range entries {
+		langs = append(langs, strings.TrimSuffix(e.Name(), path.Ext(e.Name())))
+	}
+	sort.Strings(langs[1:])
+	return langs
+}
+
+// LabelsFor returns the output labels of a language
+func LabelsFor(lang string) (meal.Labels, error) {
+	if lang == DefaultLanguage {
+		return meal.PolishLabels, nil
+	}
+	data, err := files.ReadFile("catalogs/" + lang + ".json")
+	if err != nil {
+		return meal.Labels{}, fmt.Errorf("unsupported language: %s (supported: %s)", lang, strings.Join(Languages(), ", "))
+	}
+	var labels meal.Labels
+	if err := json.Unmarshal(data, &labels); err != nil {
+		return meal.Labels{}, fmt.Errorf("labels for %s: %w", lang, err)
+	}
+	return labels, nil
+}
+
+// Dictionary maps canonical Polish names (see meal.CanonicalName) to their translations
+type Dictionary map[string]string
+
+// ParseDictionary reads a dictionary in the format of dictionaries/en.txt
+func ParseDictionary(data string) (Dictionary, error) {
+	dict := Dictionary{}
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		term, translation, ok := strings.Cut(line, "=")
+		term, translation = meal.CanonicalName(term), strings.TrimSpace(translation)
+		if !ok || term == "" || translation == "" {
+			return nil, fmt.Errorf("line %d: expected \"term = translation\"", lineNo)
+		}
+		dict[term] = translation
+	}
+	return dict, scanner.Err()
+}
+
+// Term is a name without translation and the number of times it was seen
+type Term struct {
+	Name  string
+	Count int
+}
+
+// Translator translates meal and ingredient names of plans and remembers the names it could not translate
+type Translator struct {
+	dict         Dictionary
+	untranslated map[string]int
+}
+
+// NewTranslator returns a translator into a language with a bundled dictionary
+func NewTranslator(lang string) (*Translator, error) {
+	data, err := files.ReadFile("dictionaries/" + lang + ".txt")
+	if err != nil {
+		return nil, fmt.Errorf("no dictionary for language: %s", lang)
+	}
+	dict, err := ParseDictionary(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("dictionary for %s: %w", lang, err)
+	}
+	return NewDictionaryTranslator(dict), nil
+}
+
+// NewDictionaryTranslator returns a translator using the given dictionary
+func NewDictionaryTranslator(dict Dictionary) *Translator {
+	return &Translator{dict: dict, untranslated: map[string]int{}}
+}
+
+// Plan returns a copy of the plan with meal names and ingredients translated; dish names are
+// kept, as they are proper names of the provider's recipes
+func (t *Translator) Plan(p meal.Plan) meal.Plan {
+	translated := meal.Plan{Date: p.Date, Profile: p.Profile, Meals: make([]meal.Meal, 0, len(p.Meals)), Annotations: p.Annotations}
+	for _, m := range p.Meals {
+		tm := meal.Meal{Name: t.Name(m.Name), Dishes: make([]meal.Dish, 0, len(m.Dishes))}
+		for _, d := range m.Dishes {
+			td := meal.Dish{Name: d.Name, Tags: d.Tags, Instructions: d.Instructions, Annotations: d.Annotations, Classification: d.Classification}
+			for _, ing := range d.Ingredients {
+				td.Ingredients = append(td.Ingredients, t.Ingredient(ing))
+			}
+			tm.Dishes = append(tm.Dishes, td)
+		}
+		translated.Meals = append(translated.Meals, tm)
+	}
+	return translated
+}
+
+// Ingredient translates a processed ingredient with its composition
+func (t *Translator) Ingredient(text string) string {
+	return sentenceCase(t.ingredient(meal.ParseIngredient(text)))
+}
+
+func (t *Translator) ingredient(ing meal.Ingredient) string {
+	text := t.Name(ing.Name)
+	if ing.Percentage > 0 {
+		text += " " + strconv.FormatFloat(ing.Percentage, 'f', -1, 64) + "%"
+	}
+	if len(ing.Components) > 0 {
+		components := make([]string, 0, len(ing.Components))
+		for _, c := range ing.Components {
+			components = append(components, t.ingredient(c))
+		}
+		text += " (" + strings.Join(components, ", ") + ")"
+	}
+	return text
+}
+
+// Name translates a meal or ingredient name. The longest leading run of words found in the
+// dictionary is translated and the rest, e.g. a brand or a fat content, is kept as it is.
+// Names without any match are returned unchanged and reported by Untranslated.
+func (t *Translator) Name(name string) string {
+	fields := strings.Fields(name)
+	for n := len(fields); n > 0; n-- {
+		if translation, ok := t.dict[meal.CanonicalName(strings.Join(fields[:n], " "))]; ok {
+			return strings.Join(append([]string{translation}, fields[n:]...), " ")
+		}
+	}
+	if canonical := meal.CanonicalName(name); canonical != "" {
+		t.untranslated[canonical]++
+	}
+	return name
+}
+
+// Untranslated returns the names seen without a translation, the most frequent first
+func (t *Translator) Untranslated() []Term {
+	terms := make([]Term, 0, len(t.untranslated))
+	for name, count := range t.untranslated {
+		terms = append(terms, Term{Name: name, Count: count})
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		if terms[i].Count != terms[j].Count {
+			return terms[i].Count > terms[j].Count
+		}
+		return terms[i].Name < terms[j].Name
+	})
+	return terms
+}
+
+// sentenceCase capitalises the first letter, leaving the rest as translated
+func sentenceCase(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return cases.Title(language.Und).String(string(r)) + s[size:]
+}
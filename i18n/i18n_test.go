@@ -0,0 +1,118 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/toszr/dietician/meal"
+)
+
+func TestLabelsFor(t *testing.T) {
+	t.Run("every language has complete labels", func(t *testing.T) {
+		for _, lang := range Languages() {
+			labels, err := LabelsFor(lang)
+			require.NoError(t, err, lang)
+			assert.Equal(t, lang, labels.Lang)
+			assert.NotEmpty(t, labels.Menu, lang)
+			assert.NotEmpty(t, labels.Ingredients, lang)
+			assert.NotEmpty(t, labels.Shopping, lang)
+			assert.NotEmpty(t, labels.MenuFor, lang)
+			assert.NotContains(t, labels.IngredientForms, "", lang)
+			assert.NotContains(t, labels.Weekdays, "", lang)
+			assert.NotContains(t, labels.Months, "", lang)
+		}
+	})
+
+	t.Run("every allergen has a name", func(t *testing.T) {
+		for _, lang := range Languages() {
+			labels, err := LabelsFor(lang)
+			require.NoError(t, err, lang)
+			if lang == DefaultLanguage {
+				continue
+			}
+			for _, allergen := range meal.Allergens {
+				assert.Contains(t, labels.AllergenNames, allergen.Name, lang)
+			}
+		}
+	})
+
+	t.Run("english dates", func(t *testing.T) {
+		labels, err := LabelsFor("en")
+		require.NoError(t, err)
+		date := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+		assert.Equal(t, "Menu 2026-01-02", labels.Title(date))
+		assert.Equal(t, "January 2, 2026", labels.FormatLongDate(date))
+		assert.Equal(t, "Friday", labels.Weekday(date))
+	})
+
+	t.Run("unsupported language", func(t *testing.T) {
+		_, err := LabelsFor("xx")
+		assert.ErrorContains(t, err, "unsupported language: xx")
+	})
+}
+
+func TestParseDictionary(t *testing.T) {
+	t.Run("terms are canonical", func(t *testing.T) {
+		dict, err := ParseDictionary("# comment\n\nOliwa  z Oliwek = olive oil\n")
+		require.NoError(t, err)
+		assert.Equal(t, Dictionary{"oliwa z oliwek": "olive oil"}, dict)
+	})
+
+	t.Run("missing translation", func(t *testing.T) {
+		_, err := ParseDictionary("sól\n")
+		assert.EqualError(t, err, "line 1: expected \"term = translation\"")
+	})
+
+	t.Run("bundled dictionaries", func(t *testing.T) {
+		for _, lang := range Languages()[1:] {
+			_, err := NewTranslator(lang)
+			assert.NoError(t, err, lang)
+		}
+	})
+}
+
+func TestTranslator(t *testing.T) {
+	translator := NewDictionaryTranslator(Dictionary{
+		"podwieczorek":   "Afternoon snack",
+		"śmietanka":      "cream",
+		"mąka pszenna":   "wheat flour",
+		"jaja kurze":     "hen's eggs",
+		"woda":           "water",
+		"sól":            "salt",
+		"naleśniki":      "pancakes",
+		"oliwa z oliwek": "olive oil",
+	})
+
+	t.Run("longest prefix with the rest kept", func(t *testing.T) {
+		assert.Equal(t, "Cream 30%", translator.Ingredient("Śmietanka 30%"))
+		assert.Equal(t, "Olive oil extra virgin", translator.Ingredient("Oliwa z oliwek extra virgin"))
+	})
+
+	t.Run("composition", func(t *testing.T) {
+		assert.Equal(t, "Pancakes (water, wheat flour 40%, hen's eggs)",
+			translator.Ingredient("Naleśniki (woda, mąka pszenna 40%, jaja kurze)"))
+	})
+
+	t.Run("plan", func(t *testing.T) {
+		plan := meal.Plan{Meals: []meal.Meal{{
+			Name:   "Podwieczorek",
+			Dishes: []meal.Dish{{Name: "Koktajl", Ingredients: []string{"Sól", "Kalarepa"}}},
+		}}}
+		translated := translator.Plan(plan)
+		assert.Equal(t, "Afternoon snack", translated.Meals[0].Name)
+		assert.Equal(t, "Koktajl", translated.Meals[0].Dishes[0].Name)
+		assert.Equal(t, []string{"Salt", "Kalarepa"}, translated.Meals[0].Dishes[0].Ingredients)
+		assert.Equal(t, "Sól", plan.Meals[0].Dishes[0].Ingredients[0], "the original plan is not modified")
+	})
+
+	t.Run("untranslated terms", func(t *testing.T) {
+		translator := NewDictionaryTranslator(Dictionary{"sól": "salt"})
+		translator.Ingredient("Kalarepa")
+		translator.Ingredient("Sól")
+		translator.Ingredient("Agar (karagen)")
+		translator.Ingredient("Kalarepa")
+		assert.Equal(t, []Term{{"kalarepa", 2}, {"agar", 1}, {"karagen", 1}}, translator.Untranslated())
+	})
+}
@@ -0,0 +1,166 @@
+// Package ical exports meal plans as iCalendar events, one event per meal per day.
+package ical
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/toszr/dietician/meal"
+)
+
+// Times maps meal names to their time of day, as an offset from midnight
+type Times map[string]time.Duration
+
+// DefaultTimes are the times of the meals served by the catering
+var DefaultTimes = Times{
+	"Śniadanie":    7*time.Hour + 30*time.Minute,
+	"II śniadanie": 10*time.Hour + 30*time.Minute,
+	"Obiad":        13*time.Hour + 30*time.Minute,
+	"Podwieczorek": 16*time.Hour + 30*time.Minute,
+	"Kolacja":      19 * time.Hour,
+}
+
+// DefaultDuration is the length of a meal event
+const DefaultDuration = 30 * time.Minute
+
+// ErrNoDate is returned for plans that do not know their date
+var ErrNoDate = errors.New("plan has no date")
+
+const (
+	dateTimeLayout = "20060102T150405"
+	maxLineOctets  = 75
+)
+
+// Options configure the generated events
+type Options struct {
+	Times    Times
+	Duration time.Duration
+	Labels   meal.Labels
+	// Stamp is the DTSTAMP of all events, usually the time of the export
+	Stamp time.Time
+}
+
+// ParseTimes parses meal times given as "Śniadanie=7:00,Obiad=14:30" and returns them on top of base
+func ParseTimes(s string, base Times) (Times, error) {
+	times := Times{}
+	for name, offset := range base {
+		times[name] = offset
+	}
+	for _, entry := range strings.Split(s, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		name, clock, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid meal time %q, expected name=HH:MM", entry)
+		}
+		t, err := time.Parse("15:04", strings.TrimSpace(clock))
+		if err != nil {
+			return nil, fmt.Errorf("invalid meal time %q: %w", entry, err)
+		}
+		times[strings.TrimSpace(name)] = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	}
+	return times, nil
+}
+
+// Render returns an iCalendar document with an event per meal of every plan.
+// Event times are floating, so calendars show them in their own time zone.
+func Render(plans []meal.Plan, opts Options) ([]byte, error) {
+	if opts.Duration == 0 {
+		opts.Duration = DefaultDuration
+	}
+
+	var sb strings.Builder
+	writeLine(&sb, "BEGIN:VCALENDAR")
+	writeLine(&sb, "VERSION:2.0")
+	writeLine(&sb, "PRODID:-//toszr//dietician//PL")
+	writeLine(&sb, "CALSCALE:GREGORIAN")
+	writeLine(&sb, "X-WR-CALNAME:"+escapeText(opts.Labels.Menu))
+
+	for _, plan := range plans {
+		if plan.Date.IsZero() {
+			return nil, ErrNoDate
+		}
+		for i, m := range plan.Meals {
+			offset, ok := opts.Times[m.Name]
+			if !ok {
+				return nil, fmt.Errorf("no time configured for meal %q", m.Name)
+			}
+			start := plan.Date.Add(offset)
+			writeLine(&sb, "BEGIN:VEVENT")
+			writeLine(&sb, "UID:"+uid(plan, i)+"@dietician")
+			writeLine(&sb, "DTSTAMP:"+opts.Stamp.UTC().Format(dateTimeLayout)+"Z")
+			writeLine(&sb, "DTSTART:"+start.Format(dateTimeLayout))
+			writeLine(&sb, "DTEND:"+start.Add(opts.Duration).Format(dateTimeLayout))
+			writeLine(&sb, "SUMMARY:"+escapeText(summary(m, plan.Profile)))
+			writeLine(&sb, "DESCRIPTION:"+escapeText(description(m, opts.Labels)))
+			writeLine(&sb, "END:VEVENT")
+		}
+	}
+
+	writeLine(&sb, "END:VCALENDAR")
+	return []byte(sb.String()), nil
+}
+
+// uid identifies the event of the i-th meal of the plan, telling apart the plans of different people for the same day
+func uid(plan meal.Plan, i int) string {
+	id := plan.Date.Format("20060102")
+	if slug := plan.Profile.Slug(); slug != "" {
+		id += "-" + slug
+	}
+	return id + "-" + strconv.Itoa(i+1)
+}
+
+// summary returns the meal name, with the person it is for if known, followed by the names of its dishes
+func summary(m meal.Meal, profile meal.Profile) string {
+	name := m.Name
+	if profile.Person != "" {
+		name += " (" + profile.Person + ")"
+	}
+	names := make([]string, 0, len(m.Dishes))
+	for _, dish := range m.Dishes {
+		names = append(names, dish.Name)
+	}
+	if len(names) == 0 {
+		return name
+	}
+	return name + ": " + strings.Join(names, " / ")
+}
+
+// description lists the ingredients of every dish of the meal
+func description(m meal.Meal, labels meal.Labels) string {
+	var parts []string
+	for _, dish := range m.Dishes {
+		part := dish.Name
+		if len(dish.Ingredients) > 0 {
+			part += "\n" + labels.Ingredients + ": " + strings.Join(dish.Ingredients, ", ")
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// escapeText escapes a TEXT property value as defined in RFC 5545
+func escapeText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+// writeLine writes a content line folded at 75 octets, without splitting UTF-8 characters
+func writeLine(sb *strings.Builder, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		sb.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts towards the limit
+		limit = maxLineOctets - 1
+	}
+	sb.WriteString(line + "\r\n")
+}
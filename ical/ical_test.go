@@ -0,0 +1,107 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/toszr/dietician/meal"
+)
+
+func TestRender(t *testing.T) {
+	stamp := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("event per meal", func(t *testing.T) {
+		plans := []meal.Plan{{Date: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), Meals: []meal.Meal{
+			{Name: "Śniadanie", Dishes: []meal.Dish{
+				{Name: "Jajecznica", Ingredients: []string{"Jaja kurze", "Masło"}},
+				{Name: "Owsianka; z owocami"},
+			}},
+			{Name: "Kolacja", Dishes: []meal.Dish{{Name: "Kanapki"}}},
+		}}}
+		expected := "BEGIN:VCALENDAR\r\n" +
+			"VERSION:2.0\r\n" +
+			"PRODID:-//toszr//dietician//PL\r\n" +
+			"CALSCALE:GREGORIAN\r\n" +
+			"X-WR-CALNAME:Jadłospis\r\n" +
+			"BEGIN:VEVENT\r\n" +
+			"UID:20260102-1@dietician\r\n" +
+			"DTSTAMP:20260101T120000Z\r\n" +
+			"DTSTART:20260102T073000\r\n" +
+			"DTEND:20260102T080000\r\n" +
+			"SUMMARY:Śniadanie: Jajecznica / Owsianka\\; z owocami\r\n" +
+			"DESCRIPTION:Jajecznica\\nSkładniki: Jaja kurze\\, Masło\\n\\nOwsianka\\; z owo\r\n" +
+			" cami\r\n" +
+			"END:VEVENT\r\n" +
+			"BEGIN:VEVENT\r\n" +
+			"UID:20260102-2@dietician\r\n" +
+			"DTSTAMP:20260101T120000Z\r\n" +
+			"DTSTART:20260102T190000\r\n" +
+			"DTEND:20260102T193000\r\n" +
+			"SUMMARY:Kolacja: Kanapki\r\n" +
+			"DESCRIPTION:Kanapki\r\n" +
+			"END:VEVENT\r\n" +
+			"END:VCALENDAR\r\n"
+
+		result, err := Render(plans, Options{Times: DefaultTimes, Labels: meal.PolishLabels, Stamp: stamp})
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, string(result))
+	})
+
+	t.Run("plans of several people", func(t *testing.T) {
+		date := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+		plans := []meal.Plan{
+			{Date: date, Profile: meal.Profile{Person: "Anna", Calories: 1500}, Meals: []meal.Meal{{Name: "Obiad", Dishes: []meal.Dish{{Name: "Dal"}}}}},
+			{Date: date, Profile: meal.Profile{Person: "Piotr"}, Meals: []meal.Meal{{Name: "Obiad", Dishes: []meal.Dish{{Name: "Gulasz"}}}}},
+		}
+
+		result, err := Render(plans, Options{Times: DefaultTimes, Labels: meal.PolishLabels, Stamp: stamp})
+
+		assert.NoError(t, err)
+		assert.Contains(t, string(result), "UID:20260102-anna-1500kcal-1@dietician\r\n")
+		assert.Contains(t, string(result), "SUMMARY:Obiad (Anna): Dal\r\n")
+		assert.Contains(t, string(result), "UID:20260102-piotr-1@dietician\r\n")
+		assert.Contains(t, string(result), "SUMMARY:Obiad (Piotr): Gulasz\r\n")
+	})
+
+	t.Run("plan without date", func(t *testing.T) {
+		_, err := Render([]meal.Plan{{}}, Options{Times: DefaultTimes, Labels: meal.PolishLabels, Stamp: stamp})
+		assert.ErrorIs(t, err, ErrNoDate)
+	})
+
+	t.Run("meal without time", func(t *testing.T) {
+		plans := []meal.Plan{{Date: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), Meals: []meal.Meal{{Name: "Przekąska"}}}}
+		_, err := Render(plans, Options{Times: DefaultTimes, Labels: meal.PolishLabels, Stamp: stamp})
+		assert.EqualError(t, err, `no time configured for meal "Przekąska"`)
+	})
+}
+
+func TestParseTimes(t *testing.T) {
+	t.Run("overrides and additions", func(t *testing.T) {
+		times, err := ParseTimes("Obiad=14:00, Przekąska = 21:15", DefaultTimes)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 14*time.Hour, times["Obiad"])
+		assert.Equal(t, 21*time.Hour+15*time.Minute, times["Przekąska"])
+		assert.Equal(t, DefaultTimes["Kolacja"], times["Kolacja"])
+		assert.Equal(t, 13*time.Hour+30*time.Minute, DefaultTimes["Obiad"], "base times must not be modified")
+	})
+
+	t.Run("invalid entry", func(t *testing.T) {
+		_, err := ParseTimes("Obiad", DefaultTimes)
+		assert.Error(t, err)
+	})
+}
+
+func TestWriteLine(t *testing.T) {
+	var sb strings.Builder
+	writeLine(&sb, "SUMMARY:"+strings.Repeat("ż", 40))
+
+	for _, line := range strings.Split(strings.TrimSuffix(sb.String(), "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), maxLineOctets)
+		assert.True(t, strings.HasPrefix(line, "SUMMARY:") || strings.HasPrefix(line, " ż"))
+	}
+}
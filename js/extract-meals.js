@@ -10,32 +10,71 @@ javascript:(function(){fetch('https://cdn.jsdelivr.net/gh/toszr/dietician@v0.4.0
 
 "use strict";
 
+// Version of the exported document, see meal.SchemaVersion
+const SCHEMA_VERSION = 1;
+
 function run($) {
+  // Containers of tags and badges have a class ending in __tags, __tag, __badges or __badge,
+  // e.g. dishes__tags; see isTagContainer in parser/xml.go
+  function isTagContainer() {
+    return typeof this.className === "string" && this.className.split(/\s+/).some(c => /__(tags?|badges?)$/.test(c));
+  }
+
+  // Tags: the texts of the tag containers of a node, leaving out those of the given dishes
+  function getTags(node, dishes) {
+    let tags = [];
+    const containers = node.find('*').filter(isTagContainer);
+    containers.not(dishes ? dishes.find('*') : $()).find('*').addBack().contents().each(function() {
+      const tag = this.nodeType === 3 ? $.trim(this.nodeValue) : "";
+      if (tag && !tags.includes(tag)) {
+        tags.push(tag);
+      }
+    });
+    return tags;
+  }
+
   function getMealsAndIngredients() {
     let meals = [];
 
     $('[data-cy="MealDropdownOptions_div"]').each(function() {
-      // Meal type: first non-empty text node inside the meal node
-      var mealType = $(this).find('*').addBack().contents().filter(function() {
-        return this.nodeType === 3 && $.trim(this.nodeValue) !== "";
-      }).first().text().trim();
-
-      if (!mealType) return;
-
+      let tiles = $(this).find('[data-cy="dish-tile__wrapper"]');
       let dishes = [];
-      $(this).find('[data-cy="dish-tile__wrapper"]').each(function() {
+      tiles.each(function() {
         // Dish name: all text from nodes with data-cy="MenuDishName_div"
         var dishName = $(this).find('[data-cy="MenuDishName_div"]').text().trim();
 
         // Ingredients: all text from nodes with data-cy="IngredientsAndRecipes_span"
         var ingredients = $(this).find('[data-cy="IngredientsAndRecipes_span"]').text().trim();
 
-        dishes.push({
+        let dish = {
           dishName: dishName,
           ingredientsList: ingredients
-        });
+        };
+        const badges = getTags($(this));
+        if (badges.length) {
+          dish.tags = badges;
+        }
+        dishes.push(dish);
       });
 
+      // Meal type: first non-empty text node of the header, outside of the dishes and tags,
+      // skipping the summary that repeats the name of the chosen dish
+      const dishNames = dishes.map(d => d.dishName);
+      var mealType = $(this).find('*').addBack().not($(this).find('section, [data-cy="dish-tile__wrapper"]').add($(this).find('*').filter(isTagContainer)).find('*').addBack()).contents().filter(function() {
+        const text = $.trim(this.nodeValue);
+        return this.nodeType === 3 && text !== "" && !dishNames.includes(text);
+      }).first().text().trim();
+
+      if (!mealType) return;
+
+      // Tags of the meal section describe all its dishes
+      const mealTags = getTags($(this), tiles);
+      if (mealTags.length) {
+        dishes.forEach(d => {
+          d.tags = mealTags.concat((d.tags || []).filter(t => !mealTags.includes(t)));
+        });
+      }
+
       meals.push({
         mealName: mealType,
         dishes: dishes
@@ -58,25 +97,63 @@ function run($) {
     URL.revokeObjectURL(url);
   }
 
-  function getBestFilename() {
-    let filename = 'meals.json';
+  function getDate() {
     const dateNode = $('[data-cy="DateItemDetails_div"]');
     if (dateNode.length) {
       const dateText = dateNode.text(); // Get all text inside the node
       const dateMatch = dateText.match(/(\d{2})-(\d{2})-(20\d{2})/); // Find a date with a 20xx year
       if (dateMatch) {
-        const day = dateMatch[1];
-        const month = dateMatch[2];
-        const year = dateMatch[3];
-        filename = `${day}${month}${year.slice(-2)}.json`;
+        return { day: dateMatch[1], month: dateMatch[2], year: dateMatch[3] };
       }
     }
-    return filename;
+    return null;
   }
 
-  const meals = getMealsAndIngredients();
-  const filename = getBestFilename();
-  saveToFile(meals, filename);
+  // Diet variant: the first text outside of the meals with a calorie level, e.g. "Wege 1500 kcal";
+  // the calories of dishes are shown inside the meals, see findVariant in parser/xml.go.
+  // The name keeps the text on both sides of the calorie level, as meal.ParseVariant does.
+  function getVariant() {
+    const meals = $('[data-cy="MealDropdownOptions_div"]');
+    let variant = null;
+    $('body *').not(meals.find('*').addBack()).contents().each(function() {
+      const text = this.nodeType === 3 ? $.trim(this.nodeValue) : "";
+      const match = text.match(/^(.*?)\s*\b(\d{3,5})\s*kcal\b\s*([\s\S]*)$/i);
+      if (match) {
+        const before = match[1].replace(/[\s,–-]+$/, "");
+        const after = match[3].replace(/^[\s,–-]+/, "");
+        variant = { name: `${before} ${after}`.trim(), calories: parseInt(match[2], 10) };
+        return false;
+      }
+    });
+    return variant;
+  }
+
+  // File name: the date as DDMMYY followed by the variant, see parser.FileName
+  function getBestFilename(date, variant) {
+    let name = date ? `${date.day}${date.month}${date.year.slice(-2)}` : 'meals';
+    if (variant) {
+      const words = variant.name.toLowerCase().split(/[^\p{L}\p{N}]+/u).filter(w => w);
+      name += '-' + words.concat(`${variant.calories}kcal`).join('-');
+    }
+    return `${name}.json`;
+  }
+
+  const date = getDate();
+  const variant = getVariant();
+  const plan = {
+    schemaVersion: SCHEMA_VERSION,
+    meals: getMealsAndIngredients()
+  };
+  if (date) {
+    plan.date = `${date.year}-${date.month}-${date.day}`;
+  }
+  if (variant) {
+    if (variant.name) {
+      plan.variant = variant.name;
+    }
+    plan.calories = variant.calories;
+  }
+  saveToFile(plan, getBestFilename(date, variant));
 }
 
 async function ensureJQueryLoadedAsync() {
@@ -0,0 +1,122 @@
+package layout
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+
+	"github.com/toszr/dietician/meal"
+)
+
+// Funcs returns the helper functions available in templates, with names of days and months from labels
+func Funcs(labels meal.Labels) map[string]any {
+	return map[string]any{
+		"lower": cases.Lower(language.Polish).String,
+		"upper": cases.Upper(language.Polish).String,
+		"title": cases.Title(language.Polish).String,
+		"join":  strings.Join,
+		"allergens": func(v any) ([]string, error) {
+			names, err := allergens(v)
+			return labels.AllergenNamesOf(names), err
+		},
+		"diets":    diets,
+		"date":     formatDate,
+		"weekday":  labels.Weekday,
+		"longDate": labels.FormatLongDate,
+		"plural":   plural,
+		"count":    count,
+		"labels":   func() meal.Labels { return labels },
+		"ingredientCount": func(n int) string {
+			return count(n, labels.IngredientForms[0], labels.IngredientForms[1], labels.IngredientForms[2])
+		},
+	}
+}
+
+// allergens returns the allergens of a dish, or of all dishes of a meal or plan
+func allergens(v any) ([]string, error) {
+	var dishes []meal.Dish
+	switch v := v.(type) {
+	case meal.Dish:
+		dishes = []meal.Dish{v}
+	case meal.Meal:
+		dishes = v.Dishes
+	case meal.Plan:
+		for _, m := range v.Meals {
+			dishes = append(dishes, m.Dishes...)
+		}
+	case *meal.Plan:
+		return allergens(*v)
+	default:
+		return nil, fmt.Errorf("allergens: unsupported value of type %T", v)
+	}
+
+	return meal.DishAllergens(dishes), nil
+}
+
+// diets returns the diets suited by a dish, or by every dish of a meal or plan
+func diets(v any) ([]string, error) {
+	switch v := v.(type) {
+	case meal.Dish:
+		return v.Diets(), nil
+	case meal.Meal:
+		return commonDiets(v.Dishes), nil
+	case meal.Plan:
+		var dishes []meal.Dish
+		for _, m := range v.Meals {
+			dishes = append(dishes, m.Dishes...)
+		}
+		return commonDiets(dishes), nil
+	case *meal.Plan:
+		return diets(*v)
+	default:
+		return nil, fmt.Errorf("diets: unsupported value of type %T", v)
+	}
+}
+
+// commonDiets returns the diets suited by all the dishes, in catalog order
+func commonDiets(dishes []meal.Dish) []string {
+	if len(dishes) == 0 {
+		return nil
+	}
+	count := map[string]int{}
+	for _, d := range dishes {
+		for _, diet := range d.Diets() {
+			count[diet]++
+		}
+	}
+	var common []string
+	for _, diet := range meal.Diets.Names() {
+		if count[diet] == len(dishes) {
+			common = append(common, diet)
+		}
+	}
+	return common
+}
+
+// formatDate formats a date with a Go layout, e.g. {{date .Date "02.01.2006"}}
+func formatDate(t time.Time, layout string) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(layout)
+}
+
+// plural chooses the Polish plural form for n: one (1 danie), few (2 dania) or many (5 dań)
+func plural(n int, one, few, many string) string {
+	switch {
+	case n == 1:
+		return one
+	case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+		return few
+	default:
+		return many
+	}
+}
+
+// count writes n followed by the matching plural form, e.g. {{count 5 "danie" "dania" "dań"}} is "5 dań"
+func count(n int, one, few, many string) string {
+	return fmt.Sprintf("%d %s", n, plural(n, one, few, many))
+}
@@ -0,0 +1,57 @@
+package layout
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/toszr/dietician/meal"
+)
+
+func TestPlural(t *testing.T) {
+	forms := []string{"danie", "dania", "dań"}
+	expected := map[int]string{0: "dań", 1: "danie", 2: "dania", 4: "dania", 5: "dań", 12: "dań", 14: "dań", 22: "dania", 25: "dań", 112: "dań", 123: "dania"}
+	for n, form := range expected {
+		assert.Equal(t, form, plural(n, forms[0], forms[1], forms[2]), "n = %d", n)
+	}
+	assert.Equal(t, "3 dania", count(3, forms[0], forms[1], forms[2]))
+}
+
+func TestDates(t *testing.T) {
+	date := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, "01.01.26", formatDate(date, "02.01.06"))
+	assert.Equal(t, "", formatDate(time.Time{}, "02.01.06"))
+}
+
+func TestAllergens(t *testing.T) {
+	dish := meal.Dish{Ingredients: []string{"Mleko", "Jaja kurze"}}
+	plan := meal.Plan{Meals: []meal.Meal{{Dishes: []meal.Dish{dish, {Ingredients: []string{"Seler"}}}}}}
+
+	result, err := allergens(dish)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"jaja", "mleko"}, result)
+
+	result, err = allergens(plan)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"jaja", "mleko", "seler"}, result)
+
+	_, err = allergens("Mleko")
+	assert.Error(t, err)
+}
+
+func TestDiets(t *testing.T) {
+	dish := meal.Dish{Ingredients: []string{"Mleko", "Marchew"}}
+	plan := meal.Plan{Meals: []meal.Meal{{Dishes: []meal.Dish{dish, {Ingredients: []string{"Mąka pszenna"}}}}}}
+
+	result, err := diets(dish)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"vegetarian", "pescatarian", "gluten-free", "nut-free"}, result)
+
+	result, err = diets(plan)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"vegetarian", "pescatarian", "nut-free"}, result)
+
+	_, err = diets("Mleko")
+	assert.Error(t, err)
+}
@@ -0,0 +1,105 @@
+// Package layout renders meal plans with user-defined Go templates.
+// Templates whose name ends in .html or .htm are executed with html/template, all others with text/template.
+package layout
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/toszr/dietician/meal"
+)
+
+//go:embed templates
+var bundled embed.FS
+
+// templateExt is the optional extension of template files, stripped to find the output extension
+const templateExt = ".tmpl"
+
+// Template is a parsed template ready to be executed against plans
+type Template struct {
+	name    string
+	execute func(buf *bytes.Buffer, data any) error
+}
+
+// Bundled returns the names of the templates shipped with the tool
+func Bundled() []string {
+	entries, _ := fs.ReadDir(bundled, "templates")
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Load reads a template from a file, or one of the bundled templates if there is no such file.
+// Bundled templates can be named without the .tmpl extension.
+func Load(name string, labels meal.Labels) (*Template, error) {
+	data, err := os.ReadFile(name)
+	if errors.Is(err, fs.ErrNotExist) && !strings.ContainsRune(name, filepath.Separator) {
+		return loadBundled(name, labels)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return Parse(filepath.Base(name), string(data), labels)
+}
+
+func loadBundled(name string, labels meal.Labels) (*Template, error) {
+	for _, candidate := range []string{name, name + templateExt} {
+		data, err := bundled.ReadFile(path.Join("templates", candidate))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return Parse(candidate, string(data), labels)
+	}
+	return nil, fmt.Errorf("template %s not found, bundled templates: %s", name, strings.Join(Bundled(), ", "))
+}
+
+// Parse parses the text of a template, choosing the template package by the name
+func Parse(name, text string, labels meal.Labels) (*Template, error) {
+	base := strings.TrimSuffix(name, templateExt)
+	switch strings.ToLower(filepath.Ext(base)) {
+	case ".html", ".htm":
+		t, err := htmltemplate.New(name).Funcs(Funcs(labels)).Parse(text)
+		if err != nil {
+			return nil, err
+		}
+		return &Template{name: name, execute: func(buf *bytes.Buffer, data any) error { return t.Execute(buf, data) }}, nil
+	default:
+		t, err := texttemplate.New(name).Funcs(Funcs(labels)).Parse(text)
+		if err != nil {
+			return nil, err
+		}
+		return &Template{name: name, execute: func(buf *bytes.Buffer, data any) error { return t.Execute(buf, data) }}, nil
+	}
+}
+
+// Ext returns the extension of the files the template produces, e.g. ".md" for week.md.tmpl
+func (t *Template) Ext() string {
+	return filepath.Ext(strings.TrimSuffix(t.name, templateExt))
+}
+
+// Execute runs the template against every plan and concatenates the results
+func (t *Template) Execute(plans []meal.Plan) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, plan := range plans {
+		if err := t.execute(&buf, plan); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,112 @@
+package layout
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/toszr/dietician/i18n"
+	"github.com/toszr/dietician/meal"
+)
+
+var testPlans = []meal.Plan{
+	{Date: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Meals: []meal.Meal{
+		{Name: "Obiad", Dishes: []meal.Dish{
+			{Name: "Pierogi <ruskie>", Ingredients: []string{"Mąka pszenna", "Twaróg"}},
+			{Name: "Zupa"},
+		}},
+	}},
+	{Date: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), Meals: []meal.Meal{
+		{Name: "Kolacja", Dishes: []meal.Dish{{Name: "Sałatka"}}},
+	}},
+}
+
+func TestParse(t *testing.T) {
+	t.Run("text template", func(t *testing.T) {
+		tmpl, err := Parse("menu.md.tmpl", "{{longDate .Date}}:{{range .Meals}} {{upper .Name}}{{range .Dishes}} {{.Name}}{{end}}{{end}}\n", meal.PolishLabels)
+		require.NoError(t, err)
+
+		result, err := tmpl.Execute(testPlans)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "1 stycznia 2026: OBIAD Pierogi <ruskie> Zupa\n2 stycznia 2026: KOLACJA Sałatka\n", string(result))
+		assert.Equal(t, ".md", tmpl.Ext())
+	})
+
+	t.Run("html template escapes", func(t *testing.T) {
+		tmpl, err := Parse("menu.html", "{{range .Meals}}{{range .Dishes}}<p>{{.Name}}</p>{{end}}{{end}}", meal.PolishLabels)
+		require.NoError(t, err)
+
+		result, err := tmpl.Execute(testPlans[:1])
+
+		assert.NoError(t, err)
+		assert.Equal(t, "<p>Pierogi &lt;ruskie&gt;</p><p>Zupa</p>", string(result))
+		assert.Equal(t, ".html", tmpl.Ext())
+	})
+
+	t.Run("syntax error", func(t *testing.T) {
+		_, err := Parse("menu.txt", "{{range .Meals}", meal.PolishLabels)
+		assert.Error(t, err)
+	})
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "dishes.txt")
+		require.NoError(t, os.WriteFile(path, []byte("{{range .Meals}}{{count (len .Dishes) \"danie\" \"dania\" \"dań\"}}{{end}}"), 0644))
+
+		tmpl, err := Load(path, meal.PolishLabels)
+		require.NoError(t, err)
+		result, err := tmpl.Execute(testPlans[:1])
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2 dania", string(result))
+	})
+
+	t.Run("every bundled template executes", func(t *testing.T) {
+		for _, name := range Bundled() {
+			tmpl, err := Load(name, meal.PolishLabels)
+			require.NoError(t, err, name)
+			_, err = tmpl.Execute(append(testPlans, meal.Plan{}))
+			assert.NoError(t, err, name)
+		}
+	})
+
+	t.Run("bundled template without extension", func(t *testing.T) {
+		tmpl, err := Load("checklist.md", meal.PolishLabels)
+		require.NoError(t, err)
+		result, err := tmpl.Execute(testPlans[:1])
+
+		assert.NoError(t, err)
+		assert.Equal(t, "# Jadłospis na 1 stycznia 2026 (czwartek)\n\n## Obiad\n\n- [ ] Pierogi <ruskie> (2 składniki; alergeny: gluten, mleko)\n- [ ] Zupa (0 składników)\n\n", string(result))
+	})
+
+	t.Run("bundled templates in the selected language", func(t *testing.T) {
+		labels, err := i18n.LabelsFor("en")
+		require.NoError(t, err)
+
+		tmpl, err := Load("checklist.md", labels)
+		require.NoError(t, err)
+		result, err := tmpl.Execute(testPlans[:1])
+		require.NoError(t, err)
+		assert.Equal(t, "# Menu for January 1, 2026 (Thursday)\n\n## Obiad\n\n- [ ] Pierogi <ruskie> (2 ingredients; allergens: gluten, milk)\n- [ ] Zupa (0 ingredients)\n\n", string(result))
+
+		tmpl, err = Load("card.html", labels)
+		require.NoError(t, err)
+		result, err = tmpl.Execute(testPlans[:1])
+		require.NoError(t, err)
+		assert.Contains(t, string(result), `<html lang="en">`)
+		assert.Contains(t, string(result), "<title>Menu 2026-01-01</title>")
+		assert.Contains(t, string(result), "Allergens: gluten, milk")
+		assert.NotContains(t, string(result), "Alergeny")
+	})
+
+	t.Run("unknown template", func(t *testing.T) {
+		_, err := Load("missing", meal.PolishLabels)
+		assert.ErrorContains(t, err, "bundled templates: card.html.tmpl, checklist.md.tmpl, compact.md.tmpl")
+	})
+}
@@ -0,0 +1,206 @@
+package meal
+
+import (
+	_ "embed"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+//go:embed data/additives.txt
+var additivesData string
+
+// Additives is the catalog of food additives and markers of processing, loaded from data/additives.txt
+var Additives = mustParseAdditiveCatalog(additivesData)
+
+// NOVA groups of processing, from unprocessed or minimally processed to ultra-processed foods
+const (
+	Unprocessed    = 1
+	Culinary       = 2
+	Processed      = 3
+	UltraProcessed = 4
+)
+
+// Additive is an entry of the additive catalog: an additive with its E-number, or an ingredient
+// without one that marks the NOVA group of a dish, such as a glucose syrup or salt
+type Additive struct {
+	Code     string `json:"code,omitempty" jsonschema:"E-number of the additive"`
+	Name     string `json:"name" jsonschema:"Name of the additive"`
+	Category string `json:"category" jsonschema:"Category of the additive, e.g. sweeteners"`
+	Group    int    `json:"-"`
+}
+
+// CulinaryIngredients is the category of entries that are not additives but ingredients used in home
+// cooking, such as salt, sugar and oil, which only raise the processing score of a dish
+const CulinaryIngredients = "culinary ingredients"
+
+// IsAdditive reports whether the entry is an additive or a marker of processing rather than a culinary ingredient
+func (a Additive) IsAdditive() bool {
+	return a.Category != CulinaryIngredients
+}
+
+// String returns the E-number and the name, e.g. "E330 kwas cytrynowy"
+func (a Additive) String() string {
+	if a.Code == "" {
+		return a.Name
+	}
+	return a.Code + " " + a.Name
+}
+
+// AdditiveCatalog lists additives with the terms that identify them in ingredient names
+type AdditiveCatalog struct {
+	Additives []Additive
+	// terms[i] are the terms of Additives[i]
+	terms TermCatalog
+}
+
+var (
+	additiveCategoryRegexp = regexp.MustCompile(`^\[(.+):\s*([1-4])\]$`)
+	additiveCodeRegexp     = regexp.MustCompile(`^E(\d{3,4}[a-z]?)\s+(.+)$`)
+	// eNumberRegexp matches an E-number in an ingredient name, e.g. "E330" or "e 202"
+	eNumberRegexp = regexp.MustCompile(`(?i)\bE\s?(\d{3,4}[a-z]?)\b`)
+)
+
+// ParseAdditiveCatalog reads a catalog in the format of data/additives.txt
+func ParseAdditiveCatalog(data string) (AdditiveCatalog, error) {
+	var c AdditiveCatalog
+	category, group := "", 0
+	for i, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if m := additiveCategoryRegexp.FindStringSubmatch(line); m != nil {
+			category = strings.TrimSpace(m[1])
+			group, _ = strconv.Atoi(m[2])
+			continue
+		}
+		if category == "" {
+			return AdditiveCatalog{}, fmt.Errorf("line %d: additive outside of a category", i+1)
+		}
+		if !strings.Contains(line, ":") {
+			return AdditiveCatalog{}, fmt.Errorf("line %d: expected \"E-number name: terms\"", i+1)
+		}
+		terms, err := ParseTermCatalog(line)
+		if err != nil {
+			return AdditiveCatalog{}, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		additive := Additive{Name: terms[0].Name, Category: category, Group: group}
+		if m := additiveCodeRegexp.FindStringSubmatch(additive.Name); m != nil {
+			additive.Code, additive.Name = "E"+m[1], m[2]
+		}
+		c.Additives = append(c.Additives, additive)
+		c.terms = append(c.terms, terms[0])
+	}
+	return c, nil
+}
+
+// eNumbers returns the E-numbers in an ingredient name, e.g. "E330"
+func eNumbers(name string) map[string]bool {
+	codes := map[string]bool{}
+	for _, m := range eNumberRegexp.FindAllStringSubmatch(name, -1) {
+		codes["E"+strings.ToLower(m[1])] = true
+	}
+	return codes
+}
+
+func mustParseAdditiveCatalog(data string) AdditiveCatalog {
+	c, err := ParseAdditiveCatalog(data)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Classify returns the entries named by an ingredient name, not looking into its composition.
+// An additive is named by one of its terms or by its exact E-number.
+// A generic entry without an E-number, e.g. "emulgator", is left out when the name also
+// names an additive of its category, as in "emulgator: lecytyny".
+func (c AdditiveCatalog) Classify(name string) []Additive {
+	var matched []Additive
+	ws := words(name)
+	codes := eNumbers(name)
+	coded := map[string]bool{}
+	for i, terms := range c.terms {
+		byCode := c.Additives[i].Code != "" && codes[c.Additives[i].Code] && !terms.vetoed(ws)
+		if byCode || terms.matches(ws) {
+			matched = append(matched, c.Additives[i])
+			if c.Additives[i].Code != "" {
+				coded[c.Additives[i].Category] = true
+			}
+		}
+	}
+	result := matched[:0]
+	for _, a := range matched {
+		if a.Code != "" || !coded[a.Category] || !a.IsAdditive() {
+			result = append(result, a)
+		}
+	}
+	return result
+}
+
+// processing returns the additives in the ingredients and their components, in catalog order,
+// and the NOVA group of the dish: the highest group of its entries, at least Processed for
+// a product listed with its composition and Unprocessed otherwise
+func (c AdditiveCatalog) processing(ingredients []string) ([]Additive, int) {
+	found := map[Additive]bool{}
+	group := Unprocessed
+	var walk func(ings []Ingredient)
+	walk = func(ings []Ingredient) {
+		for _, ing := range ings {
+			if len(ing.Components) > 0 {
+				group = max(group, Processed)
+			}
+			for _, a := range c.Classify(ing.Name) {
+				group = max(group, a.Group)
+				if a.IsAdditive() {
+					found[a] = true
+				}
+			}
+			walk(ing.Components)
+		}
+	}
+	walk(ParseIngredients(ingredients))
+
+	var additives []Additive
+	for _, a := range c.Additives {
+		if found[a] {
+			additives = append(additives, a)
+		}
+	}
+	return additives, group
+}
+
+// Additives returns the additives found in the dish's ingredients and their components
+func (d Dish) Additives() []Additive {
+	if d.Classification != nil {
+		return d.Classification.Additives
+	}
+	additives, _ := Additives.processing(d.Ingredients)
+	return additives
+}
+
+// Processing returns the NOVA-style group of the dish, from Unprocessed to UltraProcessed
+func (d Dish) Processing() int {
+	if d.Classification != nil {
+		return d.Classification.Processing
+	}
+	_, group := Additives.processing(d.Ingredients)
+	return group
+}
+
+// Processing returns the mean NOVA-style group of the dishes of the plan, or 0 for a plan without dishes
+func (p Plan) Processing() float64 {
+	sum, count := 0, 0
+	for _, m := range p.Meals {
+		for _, d := range m.Dishes {
+			sum += d.Processing()
+			count++
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+	return float64(sum) / float64(count)
+}
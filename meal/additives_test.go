@@ -0,0 +1,101 @@
+package meal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func additiveNames(additives []Additive) []string {
+	var names []string
+	for _, a := range additives {
+		names = append(names, a.String())
+	}
+	return names
+}
+
+func TestParseAdditiveCatalog(t *testing.T) {
+	t.Run("codes and groups", func(t *testing.T) {
+		c, err := ParseAdditiveCatalog("# comment\n[acidity regulators: 3]\nE330 kwas cytrynowy: kwasek cytrynowy\n\n[culinary ingredients: 2]\nsól: sól\n")
+
+		require.NoError(t, err)
+		assert.Equal(t, []Additive{
+			{Code: "E330", Name: "kwas cytrynowy", Category: "acidity regulators", Group: Processed},
+			{Name: "sól", Category: "culinary ingredients", Group: Culinary},
+		}, c.Additives)
+		assert.Equal(t, []string{"E330 kwas cytrynowy"}, additiveNames(c.Classify("E 330")))
+	})
+
+	t.Run("additive outside of a category", func(t *testing.T) {
+		_, err := ParseAdditiveCatalog("E330 kwas cytrynowy: kwasek cytrynowy\n")
+
+		assert.EqualError(t, err, "line 1: additive outside of a category")
+	})
+
+	t.Run("missing terms", func(t *testing.T) {
+		_, err := ParseAdditiveCatalog("[acidity regulators: 3]\nE330 kwas cytrynowy\n")
+
+		assert.EqualError(t, err, "line 2: expected \"E-number name: terms\"")
+	})
+}
+
+func TestAdditivesClassify(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected []string
+	}{
+		{"KWASEK CYTRYNOWY", []string{"E330 kwas cytrynowy"}},
+		{"Kwas askorbinowy", []string{"E300 kwas askorbinowy"}},
+		{"emulgator: lecytyny słonecznikowe", []string{"E322 lecytyny"}},
+		{"emulgator", []string{"emulgator"}},
+		{"substancja konserwująca: E211", []string{"E211 benzoesan sodu"}},
+		{"konserwant e202", []string{"E202 sorbinian potasu"}},
+		{"barwnik: karmel", []string{"E150 karmel"}},
+		{"Skrobia kukurydziana", []string{"skrobia"}},
+		{"modyfikowana skrobia ziemniaczana", []string{"skrobia modyfikowana"}},
+		{"Cebula karmelizowana", nil},
+		{"sok ekologiczny 100% z jabłek", nil},
+		{"oliwa extra 100", []string{"olej"}},
+		{"E1404", []string{"E1404 skrobia utleniona"}},
+		{"barwnik E140", []string{"E140 chlorofile"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, additiveNames(Additives.Classify(tt.name)))
+		})
+	}
+}
+
+func TestDishProcessing(t *testing.T) {
+	tests := []struct {
+		name        string
+		ingredients []string
+		additives   []string
+		group       int
+	}{
+		{"whole foods", []string{"Marchew", "Jabłko"}, nil, Unprocessed},
+		{"culinary ingredients", []string{"Marchew", "Sól", "Oliwa z oliwek"}, nil, Culinary},
+		{"processed food", []string{"Chleb żytni (mąka żytnia, woda, sól)"}, nil, Processed},
+		{"processed with an additive", []string{"Ogórki konserwowe (ogórki, ocet, kwas askorbinowy)"}, []string{"E300 kwas askorbinowy"}, Processed},
+		{"ultra-processed", []string{"Jogurt naturalny", "Ksylitol", "Sos (woda, skrobia modyfikowana)"}, []string{"E967 ksylitol", "skrobia modyfikowana"}, UltraProcessed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dish := Dish{Ingredients: tt.ingredients}
+
+			assert.Equal(t, tt.additives, additiveNames(dish.Additives()))
+			assert.Equal(t, tt.group, dish.Processing())
+		})
+	}
+}
+
+func TestPlanProcessing(t *testing.T) {
+	plan := Plan{Meals: []Meal{
+		{Name: "Śniadanie", Dishes: []Dish{{Ingredients: []string{"Jabłko"}}, {Ingredients: []string{"Ksylitol"}}}},
+		{Name: "Obiad", Dishes: []Dish{{Ingredients: []string{"Marchew", "Sól"}}}},
+	}}
+
+	assert.InDelta(t, 7.0/3, plan.Processing(), 1e-9)
+	assert.Zero(t, Plan{}.Processing())
+}
@@ -0,0 +1,209 @@
+package meal
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+//go:embed data/allergens.txt
+var allergensData string
+
+// Allergens is the catalog of allergens recognised in ingredients, loaded from data/allergens.txt
+var Allergens = mustParseTermCatalog(allergensData)
+
+// TermCatalog maps named categories to the terms that identify them in ingredient names
+type TermCatalog []TermCategory
+
+// TermCategory is a named category with the terms that match it, the exceptions that do not
+// and the vetoes that rule out the whole ingredient
+type TermCategory struct {
+	Name       string
+	Terms      [][]string
+	Exceptions [][]string
+	Vetoes     [][]string
+}
+
+// ParseTermCatalog reads a catalog in the format of data/allergens.txt
+func ParseTermCatalog(data string) (TermCatalog, error) {
+	var catalog TermCatalog
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, terms, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected \"name: terms\"", lineNo)
+		}
+		category := TermCategory{Name: strings.TrimSpace(name)}
+		for _, term := range strings.Split(terms, ",") {
+			term = strings.TrimSpace(term)
+			if exception, ok := strings.CutPrefix(term, "-"); ok {
+				category.Exceptions = append(category.Exceptions, words(exception))
+			} else if veto, ok := strings.CutPrefix(term, "!"); ok {
+				category.Vetoes = append(category.Vetoes, words(veto))
+			} else if term != "" {
+				category.Terms = append(category.Terms, words(term))
+			}
+		}
+		catalog = append(catalog, category)
+	}
+	return catalog, scanner.Err()
+}
+
+func mustParseTermCatalog(data string) TermCatalog {
+	catalog, err := ParseTermCatalog(data)
+	if err != nil {
+		panic(err)
+	}
+	return catalog
+}
+
+// Match returns the names of the categories matching any of the ingredients, in catalog order
+func (c TermCatalog) Match(ingredients []string) []string {
+	split := make([][]string, 0, len(ingredients))
+	for _, ing := range ingredients {
+		split = append(split, words(ing))
+	}
+	var matched []string
+	for _, category := range c {
+		for _, ws := range split {
+			if category.matches(ws) {
+				matched = append(matched, category.Name)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// Matches reports whether the ingredient contains one of the category's terms outside of its exceptions and vetoes
+func (c TermCategory) Matches(ingredient string) bool {
+	return c.matches(words(ingredient))
+}
+
+// matches is Matches for an ingredient split into words
+func (c TermCategory) matches(ws []string) bool {
+	if c.vetoed(ws) {
+		return false
+	}
+	excluded := make([]bool, len(ws))
+	for _, exception := range c.Exceptions {
+		for i := range ws {
+			if matchesAt(ws, i, exception) {
+				for j := range exception {
+					excluded[i+j] = true
+				}
+			}
+		}
+	}
+	for _, term := range c.Terms {
+		for i := range ws {
+			if excluded[i] || !matchesAt(ws, i, term) {
+				continue
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// Vetoed reports whether the ingredient contains one of the category's vetoes
+func (c TermCategory) Vetoed(ingredient string) bool {
+	return c.vetoed(words(ingredient))
+}
+
+// vetoed is Vetoed for an ingredient split into words
+func (c TermCategory) vetoed(ws []string) bool {
+	for _, veto := range c.Vetoes {
+		for i := range ws {
+			if matchesAt(ws, i, veto) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchesAt reports whether the words starting at i begin with the consecutive words of term
+func matchesAt(ws []string, i int, term []string) bool {
+	if i+len(term) > len(ws) {
+		return false
+	}
+	for j, t := range term {
+		if !strings.HasPrefix(ws[i+j], t) {
+			return false
+		}
+	}
+	return true
+}
+
+// wordCache keeps the words of strings split more than once
+type wordCache map[string][]string
+
+// words returns the words of s, splitting it on first use
+func (c wordCache) words(s string) []string {
+	ws, ok := c[s]
+	if !ok {
+		ws = words(s)
+		c[s] = ws
+	}
+	return ws
+}
+
+// polishLower holds casers lowercasing with Polish rules. Casers keep state between calls,
+// so they are reused through a pool rather than shared.
+var polishLower = sync.Pool{New: func() any { return cases.Lower(language.Polish) }}
+
+// words splits a lowercased string into words of letters and digits
+func words(s string) []string {
+	caser := polishLower.Get().(cases.Caser)
+	defer polishLower.Put(caser)
+	return strings.FieldsFunc(caser.String(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Allergens returns the allergens found in the dish's ingredients
+func (d Dish) Allergens() []string {
+	if d.Classification != nil {
+		return d.Classification.Allergens
+	}
+	return Allergens.Match(d.Ingredients)
+}
+
+// Allergens returns the allergens found in any dish of the plan, in catalog order
+func (p Plan) Allergens() []string {
+	var dishes []Dish
+	for _, m := range p.Meals {
+		dishes = append(dishes, m.Dishes...)
+	}
+	return DishAllergens(dishes)
+}
+
+// DishAllergens returns the allergens found in any of the dishes, in catalog order
+func DishAllergens(dishes []Dish) []string {
+	found := map[string]bool{}
+	for _, d := range dishes {
+		for _, allergen := range d.Allergens() {
+			found[allergen] = true
+		}
+	}
+	var allergens []string
+	for _, c := range Allergens {
+		if found[c.Name] {
+			allergens = append(allergens, c.Name)
+		}
+	}
+	return allergens
+}
@@ -0,0 +1,61 @@
+package meal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDishAllergens(t *testing.T) {
+	t.Run("allergens in catalog order", func(t *testing.T) {
+		dish := Dish{Ingredients: []string{
+			"Śmietanka 15%",
+			"Makaron penne (pełnoziarnisty)",
+			"Seler naciowy",
+			"Jaja kurze",
+		}}
+		assert.Equal(t, []string{"gluten", "jaja", "mleko", "seler"}, dish.Allergens())
+	})
+
+	t.Run("exceptions", func(t *testing.T) {
+		dish := Dish{Ingredients: []string{"Mleczko kokosowe realthai", "Masło orzechowe", "Mąka migdałowa", "Bułka tarta bezglutenowa"}}
+		assert.Equal(t, []string{"orzeszki ziemne", "orzechy"}, dish.Allergens())
+	})
+
+	t.Run("components of composite products", func(t *testing.T) {
+		dish := Dish{Ingredients: []string{"Sos sojowy jasny lee kum kee (woda, sól, soja, mąka pszenna)"}}
+		assert.Equal(t, []string{"gluten", "soja"}, dish.Allergens())
+	})
+
+	t.Run("no allergens", func(t *testing.T) {
+		dish := Dish{Ingredients: []string{"Marchew", "Sól morska"}}
+		assert.Empty(t, dish.Allergens())
+	})
+}
+
+func TestParseTermCatalog(t *testing.T) {
+	t.Run("terms and exceptions", func(t *testing.T) {
+		catalog, err := ParseTermCatalog("# comment\n\nmleko: mlek, ser, -mleko kokosowe, !wegański\n")
+
+		assert.NoError(t, err)
+		assert.Equal(t, TermCatalog{{
+			Name:       "mleko",
+			Terms:      [][]string{{"mlek"}, {"ser"}},
+			Exceptions: [][]string{{"mleko", "kokosowe"}},
+			Vetoes:     [][]string{{"wegański"}},
+		}}, catalog)
+	})
+
+	t.Run("invalid line", func(t *testing.T) {
+		_, err := ParseTermCatalog("mleko\n")
+		assert.EqualError(t, err, `line 1: expected "name: terms"`)
+	})
+}
+
+func TestPlanAllergens(t *testing.T) {
+	plan := Plan{Meals: []Meal{
+		{Dishes: []Dish{{Ingredients: []string{"Seler naciowy"}}}},
+		{Dishes: []Dish{{Ingredients: []string{"Jaja kurze"}}, {Ingredients: []string{"Seler korzeniowy"}}}},
+	}}
+	assert.Equal(t, []string{"jaja", "seler"}, plan.Allergens())
+}
@@ -0,0 +1,42 @@
+package meal
+
+// Classification is what the catalogs tell about the ingredients of a dish. The catalogs only know
+// Polish names, so a dish is classified before its ingredients are translated and keeps the result.
+// Classifying once also spares the callers needing several of the results repeated passes.
+type Classification struct {
+	Allergens      []string
+	Diets          []string
+	DietViolations []DietViolation
+	Additives      []Additive
+	// Processing is the NOVA-style group of the dish
+	Processing int
+}
+
+// Classified returns the dish with the classification of its ingredients kept
+func (d Dish) Classified() Dish {
+	if d.Classification == nil {
+		additives, group := Additives.processing(d.Ingredients)
+		violations := Diets.Violations(d.Ingredients)
+		d.Classification = &Classification{
+			Allergens:      d.Allergens(),
+			Diets:          Diets.suitable(d.Ingredients, violations),
+			DietViolations: violations,
+			Additives:      additives,
+			Processing:     group,
+		}
+	}
+	return d
+}
+
+// Classified returns a copy of the plan with every dish classified
+func (p Plan) Classified() Plan {
+	classified := Plan{Date: p.Date, Profile: p.Profile, Meals: make([]Meal, 0, len(p.Meals)), Annotations: p.Annotations}
+	for _, m := range p.Meals {
+		cm := Meal{Name: m.Name, Dishes: make([]Dish, 0, len(m.Dishes))}
+		for _, d := range m.Dishes {
+			cm.Dishes = append(cm.Dishes, d.Classified())
+		}
+		classified.Meals = append(classified.Meals, cm)
+	}
+	return classified
+}
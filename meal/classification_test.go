@@ -0,0 +1,21 @@
+package meal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassified(t *testing.T) {
+	plan := Plan{Meals: []Meal{{Name: "Śniadanie", Dishes: []Dish{{Name: "Owsianka", Ingredients: []string{"Płatki owsiane", "Mleko"}}}}}}
+
+	classified := plan.Classified()
+	classified.Meals[0].Dishes[0].Ingredients = []string{"Oat flakes", "Milk"}
+	dish := classified.Meals[0].Dishes[0]
+
+	assert.Nil(t, plan.Meals[0].Dishes[0].Classification)
+	assert.Equal(t, []string{"gluten", "mleko"}, dish.Allergens())
+	assert.Equal(t, []string{"gluten", "mleko"}, classified.Allergens())
+	assert.Equal(t, Diets.Suitable([]string{"Płatki owsiane", "Mleko"}), dish.Diets())
+	assert.NotEmpty(t, dish.DietViolations())
+}
@@ -0,0 +1,48 @@
+package meal
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Diagnostic describes a suspicious spot in a parsed Plan, usually caused by the provider's export
+type Diagnostic struct {
+	Meal       string `json:"meal" jsonschema:"Name of the meal"`
+	Dish       string `json:"dish,omitempty" jsonschema:"Name of the dish, if the problem concerns a dish"`
+	Ingredient string `json:"ingredient,omitempty" jsonschema:"Ingredient text, if the problem concerns an ingredient"`
+	Message    string `json:"message" jsonschema:"Description of the problem"`
+}
+
+// Diagnose reports meals without dishes, dishes without ingredients and ingredients
+// that look broken, e.g. with unbalanced parentheses or split in the middle of a number
+func (p *Plan) Diagnose() []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, meal := range p.Meals {
+		if len(meal.Dishes) == 0 {
+			diagnostics = append(diagnostics, Diagnostic{Meal: meal.Name, Message: "meal has no dishes"})
+		}
+		for _, dish := range meal.Dishes {
+			if len(dish.Ingredients) == 0 {
+				diagnostics = append(diagnostics, Diagnostic{Meal: meal.Name, Dish: dish.Name, Message: "dish has no ingredients"})
+			}
+			for _, ing := range dish.Ingredients {
+				if msg := diagnoseIngredient(ing); msg != "" {
+					diagnostics = append(diagnostics, Diagnostic{Meal: meal.Name, Dish: dish.Name, Ingredient: ing, Message: msg})
+				}
+			}
+		}
+	}
+	return diagnostics
+}
+
+// diagnoseIngredient returns a description of what looks wrong with the ingredient, or an empty string
+func diagnoseIngredient(ing string) string {
+	if r, _ := utf8.DecodeRuneInString(ing); unicode.IsDigit(r) {
+		return "ingredient starts with a digit, probably a fragment of the previous ingredient"
+	}
+	if open, closed := strings.Count(ing, "("), strings.Count(ing, ")"); open != closed {
+		return "unbalanced parentheses"
+	}
+	return ""
+}
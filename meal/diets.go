@@ -0,0 +1,195 @@
+package meal
+
+import (
+	_ "embed"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+//go:embed data/diets.txt
+var dietsData string
+
+//go:embed data/foodgroups.txt
+var foodGroupsData string
+
+// Diets is the catalog of diets recognised in dishes, loaded from data/diets.txt and data/foodgroups.txt
+var Diets = mustParseDietCatalog(dietsData, foodGroupsData)
+
+// Diet is a diet with the food groups it excludes
+type Diet struct {
+	Name     string
+	Excludes []string
+}
+
+// DietCatalog lists diets and the food groups they exclude
+type DietCatalog struct {
+	Diets  []Diet
+	Groups TermCatalog
+}
+
+// DietViolation records an ingredient that rules a dish out of a diet. Components of composite
+// ingredients are named with their parent, e.g. "Chleb / mąka pszenna".
+type DietViolation struct {
+	Diet       string `json:"diet" jsonschema:"Name of the diet, e.g. vegan"`
+	Group      string `json:"group" jsonschema:"Food group excluded by the diet, e.g. nabiał"`
+	Ingredient string `json:"ingredient" jsonschema:"Ingredient belonging to the food group"`
+}
+
+// ParseDietCatalog reads diets in the format of data/diets.txt and the food groups they
+// exclude in the format of data/foodgroups.txt
+func ParseDietCatalog(diets, groups string) (DietCatalog, error) {
+	groupCatalog, err := ParseTermCatalog(groups)
+	if err != nil {
+		return DietCatalog{}, fmt.Errorf("food groups: %w", err)
+	}
+	known := map[string]bool{}
+	for _, g := range groupCatalog {
+		known[g.Name] = true
+	}
+
+	c := DietCatalog{Groups: groupCatalog}
+	for i, line := range strings.Split(diets, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, excludes, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return DietCatalog{}, fmt.Errorf("diets: line %d: expected \"diet: groups\"", i+1)
+		}
+		diet := Diet{Name: strings.TrimSpace(name)}
+		for _, group := range strings.Split(excludes, ",") {
+			group = strings.TrimSpace(group)
+			if group == "" {
+				continue
+			}
+			if !known[group] {
+				return DietCatalog{}, fmt.Errorf("diets: line %d: unknown food group %q", i+1, group)
+			}
+			diet.Excludes = append(diet.Excludes, group)
+		}
+		c.Diets = append(c.Diets, diet)
+	}
+	return c, nil
+}
+
+func mustParseDietCatalog(diets, groups string) DietCatalog {
+	c, err := ParseDietCatalog(diets, groups)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Diet returns the diet with the given name
+func (c DietCatalog) Diet(name string) (Diet, bool) {
+	for _, d := range c.Diets {
+		if d.Name == name {
+			return d, true
+		}
+	}
+	return Diet{}, false
+}
+
+// Names returns the names of all diets in catalog order
+func (c DietCatalog) Names() []string {
+	names := make([]string, 0, len(c.Diets))
+	for _, d := range c.Diets {
+		names = append(names, d.Name)
+	}
+	return names
+}
+
+// Violations returns the ingredients that rule the ingredient list out of each diet, in catalog order
+func (c DietCatalog) Violations(ingredients []string) []DietViolation {
+	parsed := ParseIngredients(ingredients)
+	split := wordCache{}
+	found := map[string][]string{}
+	for _, g := range c.Groups {
+		found[g.Name] = groupIngredients(g, parsed, "", split)
+	}
+
+	var violations []DietViolation
+	for _, d := range c.Diets {
+		for _, group := range d.Excludes {
+			for _, ing := range found[group] {
+				violations = append(violations, DietViolation{Diet: d.Name, Group: group, Ingredient: ing})
+			}
+		}
+	}
+	return violations
+}
+
+// Suitable returns the names of the diets the ingredient list suits, in catalog order.
+// An empty list suits no diet, as nothing is known about the dish.
+func (c DietCatalog) Suitable(ingredients []string) []string {
+	if len(ingredients) == 0 {
+		return nil
+	}
+	return c.suitable(ingredients, c.Violations(ingredients))
+}
+
+// suitable is Suitable with the violations of the ingredients already found
+func (c DietCatalog) suitable(ingredients []string, violations []DietViolation) []string {
+	if len(ingredients) == 0 {
+		return nil
+	}
+	violated := map[string]bool{}
+	for _, v := range violations {
+		violated[v.Diet] = true
+	}
+	var suitable []string
+	for _, d := range c.Diets {
+		if !violated[d.Name] {
+			suitable = append(suitable, d.Name)
+		}
+	}
+	return suitable
+}
+
+// groupIngredients returns the names of the ingredients belonging to a food group. The components
+// of a matching ingredient are not reported again, and those of a vetoed one are not looked into.
+// Vetoes are looked for in the whole text, as in "Jogurt naturalny (bez laktozy)".
+func groupIngredients(group TermCategory, ings []Ingredient, parent string, split wordCache) []string {
+	var names []string
+	for _, ing := range ings {
+		if group.vetoed(split.words(ing.Text)) {
+			continue
+		}
+		name := parent + ing.Name
+		if group.matches(split.words(ing.Name)) {
+			names = append(names, name)
+			continue
+		}
+		names = append(names, groupIngredients(group, ing.Components, name+componentSeparator, split)...)
+	}
+	return names
+}
+
+// Suits reports whether the dish suits all the diets
+func (d Dish) Suits(diets []string) bool {
+	suitable := d.Diets()
+	for _, diet := range diets {
+		if !slices.Contains(suitable, diet) {
+			return false
+		}
+	}
+	return true
+}
+
+// Diets returns the names of the diets the dish suits
+func (d Dish) Diets() []string {
+	if d.Classification != nil {
+		return d.Classification.Diets
+	}
+	return Diets.Suitable(d.Ingredients)
+}
+
+// DietViolations returns the ingredients that rule the dish out of each diet
+func (d Dish) DietViolations() []DietViolation {
+	if d.Classification != nil {
+		return d.Classification.DietViolations
+	}
+	return Diets.Violations(d.Ingredients)
+}
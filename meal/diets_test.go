@@ -0,0 +1,88 @@
+package meal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDietCatalog(t *testing.T) {
+	t.Run("diets with groups", func(t *testing.T) {
+		catalog, err := ParseDietCatalog("# comment\n\nvegan: mięso, nabiał\nany:\n", "mięso: mięs\nnabiał: mlek\n")
+
+		require.NoError(t, err)
+		assert.Equal(t, []Diet{{Name: "vegan", Excludes: []string{"mięso", "nabiał"}}, {Name: "any"}}, catalog.Diets)
+		assert.Equal(t, []string{"vegan", "any"}, catalog.Names())
+	})
+
+	t.Run("unknown food group", func(t *testing.T) {
+		_, err := ParseDietCatalog("vegan: mięso, ryby\n", "mięso: mięs\n")
+		assert.EqualError(t, err, `diets: line 1: unknown food group "ryby"`)
+	})
+
+	t.Run("invalid diet line", func(t *testing.T) {
+		_, err := ParseDietCatalog("vegan\n", "mięso: mięs\n")
+		assert.EqualError(t, err, `diets: line 1: expected "diet: groups"`)
+	})
+
+	t.Run("invalid food groups", func(t *testing.T) {
+		_, err := ParseDietCatalog("vegan: mięso\n", "mięso\n")
+		assert.EqualError(t, err, `food groups: line 1: expected "name: terms"`)
+	})
+}
+
+func TestDishDiets(t *testing.T) {
+	t.Run("plant-based dish", func(t *testing.T) {
+		dish := Dish{Ingredients: []string{"Ciecierzyca", "Pomidory pelati", "Oliwa z oliwek"}}
+		assert.Equal(t, []string{"vegetarian", "pescatarian", "vegan", "gluten-free", "lactose-free", "nut-free"}, dish.Diets())
+		assert.Empty(t, dish.DietViolations())
+	})
+
+	t.Run("fish and dairy", func(t *testing.T) {
+		dish := Dish{Ingredients: []string{"Łosoś atlantycki", "Jogurt naturalny", "Koper"}}
+		assert.Equal(t, []string{"pescatarian", "gluten-free", "nut-free"}, dish.Diets())
+		assert.Equal(t, []DietViolation{
+			{Diet: "vegetarian", Group: "ryby", Ingredient: "Łosoś atlantycki"},
+			{Diet: "vegan", Group: "ryby", Ingredient: "Łosoś atlantycki"},
+			{Diet: "vegan", Group: "nabiał", Ingredient: "Jogurt naturalny"},
+			{Diet: "lactose-free", Group: "laktoza", Ingredient: "Jogurt naturalny"},
+		}, dish.DietViolations())
+	})
+
+	t.Run("components named with their product", func(t *testing.T) {
+		dish := Dish{Ingredients: []string{"Tortilla (mąka pszenna, woda, sól)"}}
+		assert.Equal(t, []DietViolation{
+			{Diet: "gluten-free", Group: "gluten", Ingredient: "Tortilla"},
+		}, dish.DietViolations())
+
+		dish = Dish{Ingredients: []string{"Sos sojowy (woda, soja, pszenica)"}}
+		assert.Equal(t, []DietViolation{
+			{Diet: "gluten-free", Group: "gluten", Ingredient: "Sos sojowy / pszenica"},
+		}, dish.DietViolations())
+	})
+
+	t.Run("vetoes", func(t *testing.T) {
+		dish := Dish{Ingredients: []string{"Wegański jak kurczak", "Jogurt naturalny (bez laktozy)"}}
+		assert.Equal(t, []string{"vegetarian", "pescatarian", "gluten-free", "lactose-free", "nut-free"}, dish.Diets())
+	})
+
+	t.Run("no ingredients", func(t *testing.T) {
+		assert.Empty(t, Dish{Name: "Herbata"}.Diets())
+	})
+}
+
+func TestFilterSuits(t *testing.T) {
+	soup := Dish{Name: "Zupa", Ingredients: []string{"Woda", "Marchew"}}
+	plan := Plan{Annotations: []string{"note"}, Meals: []Meal{
+		{Name: "Obiad", Dishes: []Dish{soup, {Name: "Kotlet", Ingredients: []string{"Schab"}}}},
+		{Name: "Kolacja", Dishes: []Dish{{Name: "Kanapka", Ingredients: []string{"Szynka"}}}},
+	}}
+
+	suits := func(diets []string) func(Dish) bool {
+		return func(d Dish) bool { return d.Suits(diets) }
+	}
+
+	assert.Equal(t, Plan{Annotations: []string{"note"}, Meals: []Meal{{Name: "Obiad", Dishes: []Dish{soup}}}}, plan.Filter(suits([]string{"vegan"})))
+	assert.Equal(t, plan.Meals, plan.Filter(suits(nil)).Meals)
+}
@@ -0,0 +1,215 @@
+package meal
+
+import (
+	"strconv"
+	"strings"
+)
+
+// DishDiff lists how the ingredients, tags and instructions of a dish changed between two servings.
+// Components of composite ingredients are named with their parent, e.g. "Bulion warzywny / seler".
+type DishDiff struct {
+	Added               []string           `json:"added,omitempty"`
+	Removed             []string           `json:"removed,omitempty"`
+	Reordered           []string           `json:"reordered,omitempty"`
+	Percentages         []PercentageChange `json:"percentages,omitempty"`
+	AddedTags           []string           `json:"addedTags,omitempty"`
+	RemovedTags         []string           `json:"removedTags,omitempty"`
+	AddedInstructions   []string           `json:"addedInstructions,omitempty"`
+	RemovedInstructions []string           `json:"removedInstructions,omitempty"`
+}
+
+// PercentageChange is a changed share of an ingredient, or a changed fat content such as "Śmietanka 30%"
+type PercentageChange struct {
+	Name string  `json:"name"`
+	From float64 `json:"from"`
+	To   float64 `json:"to"`
+}
+
+// componentSeparator joins the names of a component and its parent in a DishDiff
+const componentSeparator = " / "
+
+// DiffDishes compares the ingredients of two servings of a dish. Ingredients are matched by their
+// canonical names without a trailing percentage, so a changed fat content is a percentage change
+// and not a swap of ingredients; a brand change shows as one ingredient removed and another added.
+// Tags are matched by their canonical names and instructions as written.
+func DiffDishes(from, to Dish) DishDiff {
+	var diff DishDiff
+	diff.compare(ParseIngredients(from.Ingredients), ParseIngredients(to.Ingredients), "", true)
+	diff.AddedTags, diff.RemovedTags = diffSets(from.Tags, to.Tags, CanonicalName)
+	diff.AddedInstructions, diff.RemovedInstructions = diffSets(from.Instructions, to.Instructions, strings.TrimSpace)
+	return diff
+}
+
+// IsEmpty reports whether the servings have the same ingredients in the same order, tags and instructions
+func (d DishDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Reordered) == 0 && len(d.Percentages) == 0 &&
+		len(d.AddedTags) == 0 && len(d.RemovedTags) == 0 && len(d.AddedInstructions) == 0 && len(d.RemovedInstructions) == 0
+}
+
+// String summarises the changes, e.g. "+Ksylitol; −Cukier; ↕Mąka pszenna; Śmietanka 30% → 18%; +#wege; −"Podgrzać"",
+// with tags after "#" and instructions in quotes
+func (d DishDiff) String() string {
+	var parts []string
+	for _, name := range d.Added {
+		parts = append(parts, "+"+name)
+	}
+	for _, name := range d.Removed {
+		parts = append(parts, "−"+name)
+	}
+	for _, name := range d.Reordered {
+		parts = append(parts, "↕"+name)
+	}
+	for _, p := range d.Percentages {
+		parts = append(parts, p.Name+" "+formatPercent(p.From)+" → "+formatPercent(p.To))
+	}
+	for _, tag := range d.AddedTags {
+		parts = append(parts, "+#"+tag)
+	}
+	for _, tag := range d.RemovedTags {
+		parts = append(parts, "−#"+tag)
+	}
+	for _, instruction := range d.AddedInstructions {
+		parts = append(parts, `+"`+instruction+`"`)
+	}
+	for _, instruction := range d.RemovedInstructions {
+		parts = append(parts, `−"`+instruction+`"`)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// diffSets returns the values only in to and those only in from, compared by their keys
+func diffSets(from, to []string, key func(string) string) (added, removed []string) {
+	fromKeys := map[string]bool{}
+	for _, v := range from {
+		fromKeys[key(v)] = true
+	}
+	toKeys := map[string]bool{}
+	for _, v := range to {
+		toKeys[key(v)] = true
+		if !fromKeys[key(v)] {
+			added = append(added, v)
+		}
+	}
+	for _, v := range from {
+		if !toKeys[key(v)] {
+			removed = append(removed, v)
+		}
+	}
+	return added, removed
+}
+
+func formatPercent(p float64) string {
+	return strconv.FormatFloat(p, 'f', -1, 64) + "%"
+}
+
+// compare records the differences between two lists of ingredients of the same parent
+func (d *DishDiff) compare(from, to []Ingredient, parent string, topLevel bool) {
+	fromKeys := make([]string, len(from))
+	fromByKey := map[string]Ingredient{}
+	for i, ing := range from {
+		fromKeys[i] = diffKey(ing, topLevel)
+		fromByKey[fromKeys[i]] = ing
+	}
+	toKeys := make([]string, len(to))
+	toByKey := map[string]Ingredient{}
+	for i, ing := range to {
+		toKeys[i] = diffKey(ing, topLevel)
+		toByKey[toKeys[i]] = ing
+	}
+
+	for i, key := range toKeys {
+		if _, ok := fromByKey[key]; !ok {
+			d.Added = append(d.Added, parent+baseName(to[i], topLevel))
+		}
+	}
+	for i, key := range fromKeys {
+		if _, ok := toByKey[key]; !ok {
+			d.Removed = append(d.Removed, parent+baseName(from[i], topLevel))
+		}
+	}
+
+	// Ingredients kept in both lists but outside their longest common subsequence were moved
+	var commonFrom, commonTo []string
+	for _, key := range fromKeys {
+		if _, ok := toByKey[key]; ok {
+			commonFrom = append(commonFrom, key)
+		}
+	}
+	for _, key := range toKeys {
+		if _, ok := fromByKey[key]; ok {
+			commonTo = append(commonTo, key)
+		}
+	}
+	inOrder := longestCommonSubsequence(commonFrom, commonTo)
+	for _, key := range commonTo {
+		if !inOrder[key] {
+			d.Reordered = append(d.Reordered, parent+baseName(toByKey[key], topLevel))
+		}
+	}
+
+	for _, key := range commonTo {
+		a, b := fromByKey[key], toByKey[key]
+		name := parent + baseName(b, topLevel)
+		if pa, pb := diffPercentage(a, topLevel), diffPercentage(b, topLevel); pa != pb {
+			d.Percentages = append(d.Percentages, PercentageChange{Name: name, From: pa, To: pb})
+		}
+		d.compare(a.Components, b.Components, name+componentSeparator, false)
+	}
+}
+
+// diffKey identifies an ingredient across servings: its canonical name without a trailing percentage
+func diffKey(ing Ingredient, topLevel bool) string {
+	return CanonicalName(baseName(ing, topLevel))
+}
+
+// baseName returns the name of a top-level ingredient without a trailing percentage, e.g. "Śmietanka" for
+// "Śmietanka 30%"; components have their percentage parsed out already
+func baseName(ing Ingredient, topLevel bool) string {
+	if topLevel {
+		if m := componentPercentageRegexp.FindStringSubmatch(ing.Name); m != nil && m[1] != "" {
+			return m[1]
+		}
+	}
+	return ing.Name
+}
+
+// diffPercentage returns the percentage of a component, or the trailing percentage of a top-level name
+func diffPercentage(ing Ingredient, topLevel bool) float64 {
+	if topLevel {
+		if m := componentPercentageRegexp.FindStringSubmatch(ing.Name); m != nil && m[1] != "" {
+			return parsePercentage(m[2])
+		}
+	}
+	return ing.Percentage
+}
+
+// longestCommonSubsequence returns the elements of one longest common subsequence of a and b
+func longestCommonSubsequence(a, b []string) map[string]bool {
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+	common := map[string]bool{}
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			common[a[i]] = true
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return common
+}
@@ -0,0 +1,67 @@
+package meal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffDishes(t *testing.T) {
+	t.Run("same ingredients", func(t *testing.T) {
+		dish := Dish{Ingredients: []string{"Mąka pszenna", "Cukier"}}
+		diff := DiffDishes(dish, Dish{Ingredients: []string{"mąka  pszenna", "Cukier"}})
+
+		assert.True(t, diff.IsEmpty())
+		assert.Empty(t, diff.String())
+	})
+
+	t.Run("added, removed and reordered", func(t *testing.T) {
+		from := Dish{Ingredients: []string{"Mąka pszenna", "Cukier", "Jaja kurze", "Masło"}}
+		to := Dish{Ingredients: []string{"Masło", "Mąka pszenna", "Jaja kurze", "Ksylitol"}}
+
+		diff := DiffDishes(from, to)
+
+		assert.Equal(t, []string{"Ksylitol"}, diff.Added)
+		assert.Equal(t, []string{"Cukier"}, diff.Removed)
+		assert.Equal(t, []string{"Masło"}, diff.Reordered)
+		assert.Equal(t, "+Ksylitol; −Cukier; ↕Masło", diff.String())
+	})
+
+	t.Run("percentage changes", func(t *testing.T) {
+		from := Dish{Ingredients: []string{"Śmietanka 30%", "Wanilia (perły wanilii (62.5%), koncentrat 37.5%)"}}
+		to := Dish{Ingredients: []string{"Śmietanka 18%", "Wanilia (perły wanilii (50%), koncentrat 37.5%)"}}
+
+		diff := DiffDishes(from, to)
+
+		assert.Equal(t, []PercentageChange{
+			{Name: "Śmietanka", From: 30, To: 18},
+			{Name: "Wanilia / perły wanilii", From: 62.5, To: 50},
+		}, diff.Percentages)
+		assert.Equal(t, "Śmietanka 30% → 18%; Wanilia / perły wanilii 62.5% → 50%", diff.String())
+	})
+
+	t.Run("changed composition", func(t *testing.T) {
+		from := Dish{Ingredients: []string{"Bulion warzywny (woda, seler, sól)"}}
+		to := Dish{Ingredients: []string{"Bulion warzywny (woda, marchew, sól)"}}
+
+		diff := DiffDishes(from, to)
+
+		assert.Equal(t, []string{"Bulion warzywny / marchew"}, diff.Added)
+		assert.Equal(t, []string{"Bulion warzywny / seler"}, diff.Removed)
+	})
+
+	t.Run("tags and instructions", func(t *testing.T) {
+		from := Dish{Ingredients: []string{"Pomidory"}, Tags: []string{"Wege", "nowość"}, Instructions: []string{"Podgrzać"}}
+		to := Dish{Ingredients: []string{"Pomidory"}, Tags: []string{"wege", "ostre"}, Instructions: []string{"Podawać na zimno"}}
+
+		diff := DiffDishes(from, to)
+
+		require.False(t, diff.IsEmpty())
+		assert.Equal(t, []string{"ostre"}, diff.AddedTags)
+		assert.Equal(t, []string{"nowość"}, diff.RemovedTags)
+		assert.Equal(t, []string{"Podawać na zimno"}, diff.AddedInstructions)
+		assert.Equal(t, []string{"Podgrzać"}, diff.RemovedInstructions)
+		assert.Equal(t, `+#ostre; −#nowość; +"Podawać na zimno"; −"Podgrzać"`, diff.String())
+	})
+}
@@ -0,0 +1,106 @@
+package meal
+
+import (
+	"encoding/json"
+	"math"
+)
+
+// SchemaVersion is the version of the canonical JSON document written by FormatToJSON
+const SchemaVersion = 1
+
+// dateLayout is the layout of dates in the canonical JSON document
+const dateLayout = "2006-01-02"
+
+// Document is the canonical, versioned JSON representation of a processed Plan
+type Document struct {
+	SchemaVersion int            `json:"schemaVersion" jsonschema:"Version of this document format"`
+	Date          string         `json:"date,omitempty" jsonschema:"Day of the plan (YYYY-MM-DD)"`
+	Person        string         `json:"person,omitempty" jsonschema:"Person the plan is for"`
+	Variant       string         `json:"variant,omitempty" jsonschema:"Diet variant ordered from the provider, e.g. wege"`
+	Calories      int            `json:"calories,omitempty" jsonschema:"Calorie target of the variant in kcal, e.g. 1500"`
+	Meals         []DocumentMeal `json:"meals" jsonschema:"Meals in the order of the menu"`
+	Processing    float64        `json:"processing,omitempty" jsonschema:"Mean NOVA-style processing group of the dishes, from 1 (unprocessed) to 4 (ultra-processed)"`
+	Diagnostics   []Diagnostic   `json:"diagnostics,omitempty" jsonschema:"Problems found while processing the plan"`
+}
+
+// DocumentMeal is a meal in the canonical JSON document
+type DocumentMeal struct {
+	Name   string         `json:"mealName" jsonschema:"Name of the meal, e.g. Śniadanie"`
+	Dishes []DocumentDish `json:"dishes" jsonschema:"Dishes to choose from"`
+}
+
+// DocumentDish is a dish with structured ingredients in the canonical JSON document.
+// Documents written by js/extract-meals.js carry the raw IngredientsList instead,
+// which is processed when the document is read.
+type DocumentDish struct {
+	Name            string          `json:"dishName" jsonschema:"Name of the dish"`
+	Ingredients     []Ingredient    `json:"ingredients,omitempty" jsonschema:"Processed ingredients in the order of the menu"`
+	IngredientsList string          `json:"ingredientsList,omitempty" jsonschema:"Raw ingredient list as shown by the provider, used when ingredients are missing"`
+	Tags            []string        `json:"tags,omitempty" jsonschema:"Labels of the dish given by the provider, e.g. wege"`
+	Instructions    []string        `json:"instructions,omitempty" jsonschema:"Serving and heating instructions given with the name of the dish, e.g. Lekko podgrzać"`
+	Additives       []Additive      `json:"additives,omitempty" jsonschema:"Food additives found in the ingredients and their components"`
+	Processing      int             `json:"processing,omitempty" jsonschema:"NOVA-style processing group, from 1 (unprocessed) to 4 (ultra-processed)"`
+	Diets           []string        `json:"diets,omitempty" jsonschema:"Diets the dish suits, e.g. vegetarian"`
+	DietViolations  []DietViolation `json:"dietViolations,omitempty" jsonschema:"Ingredients that rule the dish out of the other diets"`
+}
+
+// ToDocument converts the Plan to its canonical JSON document with the fields derived from the
+// ingredients: additives, processing, diets and diagnostics
+func (p *Plan) ToDocument() Document {
+	doc := p.document()
+	doc.Diagnostics = p.Diagnose()
+	sum, count := 0, 0
+	for i, meal := range p.Meals {
+		for j, dish := range meal.Dishes {
+			c := dish.Classified().Classification
+			docDish := &doc.Meals[i].Dishes[j]
+			docDish.Additives = c.Additives
+			docDish.Processing = c.Processing
+			docDish.Diets = c.Diets
+			docDish.DietViolations = c.DietViolations
+			sum += c.Processing
+			count++
+		}
+	}
+	if count > 0 {
+		doc.Processing = math.Round(float64(sum)/float64(count)*100) / 100
+	}
+	return doc
+}
+
+// document converts the Plan to a JSON document without derived fields
+func (p *Plan) document() Document {
+	doc := Document{
+		SchemaVersion: SchemaVersion,
+		Person:        p.Profile.Person,
+		Variant:       p.Profile.Variant,
+		Calories:      p.Profile.Calories,
+		Meals:         make([]DocumentMeal, 0, len(p.Meals)),
+	}
+	if !p.Date.IsZero() {
+		doc.Date = p.Date.Format(dateLayout)
+	}
+
+	for _, meal := range p.Meals {
+		docMeal := DocumentMeal{Name: meal.Name, Dishes: make([]DocumentDish, 0, len(meal.Dishes))}
+		for _, dish := range meal.Dishes {
+			docMeal.Dishes = append(docMeal.Dishes, DocumentDish{
+				Name:         dish.Name,
+				Ingredients:  ParseIngredients(dish.Ingredients),
+				Tags:         dish.Tags,
+				Instructions: dish.Instructions,
+			})
+		}
+		doc.Meals = append(doc.Meals, docMeal)
+	}
+	return doc
+}
+
+// FormatToJSON converts a meal Plan to the canonical JSON document
+func (p *Plan) FormatToJSON() (string, error) {
+	data, err := json.MarshalIndent(p.ToDocument(), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
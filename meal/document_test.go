@@ -0,0 +1,107 @@
+package meal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatToJSON(t *testing.T) {
+	t.Run("plan with date", func(t *testing.T) {
+		plan := Plan{
+			Date: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			Meals: []Meal{
+				{Name: "Obiad", Dishes: []Dish{
+					{Name: "Zupa", Ingredients: []string{"Bulion (woda, sól)"}},
+				}},
+			},
+		}
+		expected := `{
+  "schemaVersion": 1,
+  "date": "2026-01-01",
+  "meals": [
+    {
+      "mealName": "Obiad",
+      "dishes": [
+        {
+          "dishName": "Zupa",
+          "ingredients": [
+            {
+              "text": "Bulion (woda, sól)",
+              "name": "Bulion",
+              "components": [
+                {
+                  "text": "woda",
+                  "name": "woda"
+                },
+                {
+                  "text": "sól",
+                  "name": "sól"
+                }
+              ]
+            }
+          ],
+          "processing": 3,
+          "diets": [
+            "vegetarian",
+            "pescatarian",
+            "vegan",
+            "gluten-free",
+            "lactose-free",
+            "nut-free"
+          ]
+        }
+      ]
+    }
+  ],
+  "processing": 3
+}
+`
+
+		result, err := plan.FormatToJSON()
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("empty plan", func(t *testing.T) {
+		plan := Plan{}
+
+		result, err := plan.FormatToJSON()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "{\n  \"schemaVersion\": 1,\n  \"meals\": []\n}\n", result)
+	})
+}
+
+func TestDiagnose(t *testing.T) {
+	plan := Plan{Meals: []Meal{
+		{Name: "Śniadanie"},
+		{Name: "Obiad", Dishes: []Dish{
+			{Name: "Chleb"},
+			{Name: "Mleko", Ingredients: []string{"Mleko bezlaktozowe 1", "5%uht", "Kwas askorbinowy)", "Woda"}},
+		}},
+	}}
+	expected := []Diagnostic{
+		{Meal: "Śniadanie", Message: "meal has no dishes"},
+		{Meal: "Obiad", Dish: "Chleb", Message: "dish has no ingredients"},
+		{Meal: "Obiad", Dish: "Mleko", Ingredient: "5%uht", Message: "ingredient starts with a digit, probably a fragment of the previous ingredient"},
+		{Meal: "Obiad", Dish: "Mleko", Ingredient: "Kwas askorbinowy)", Message: "unbalanced parentheses"},
+	}
+
+	assert.Equal(t, expected, plan.Diagnose())
+}
+
+func TestJSONSchemaIsPublished(t *testing.T) {
+	published, err := os.ReadFile(filepath.Join("..", "schema", "plan.schema.json"))
+	require.NoError(t, err)
+
+	schema, err := JSONSchema()
+
+	assert.NoError(t, err)
+	assert.Equal(t, string(published), schema, "schema/plan.schema.json is out of date, regenerate it with -schema")
+}
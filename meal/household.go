@@ -0,0 +1,280 @@
+package meal
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrMixedDates is returned when merging plans of different days into a household
+var ErrMixedDates = errors.New("plans are for different days")
+
+// Household is the plans of several people for the same day merged by meal
+type Household struct {
+	Date time.Time
+	// Members are the profiles of the merged plans, in the order of the plans
+	Members []Profile
+	Meals   []HouseholdMeal
+}
+
+// HouseholdMeal is a meal of any of the merged plans
+type HouseholdMeal struct {
+	Name   string
+	Dishes []HouseholdDish
+}
+
+// HouseholdDish is a dish with the people eating it. A dish in the plans of several people is
+// listed once, with the ingredients of the first plan.
+type HouseholdDish struct {
+	Dish
+	People []string
+}
+
+// ShoppingItem is an ingredient to buy with the number of servings of the dishes it is in
+type ShoppingItem struct {
+	Name     string
+	Servings int
+}
+
+// AllergenExposure is an allergen with the people eating dishes that contain it
+type AllergenExposure struct {
+	Allergen string
+	People   []string
+}
+
+// NewHousehold merges the plans of several people for the same day. Every plan needs a person of
+// its own, or a variant for plans without one, as in the archive; meals are matched by name and
+// dishes by their canonical names. A meal missing from the earlier plans is placed after the meal
+// preceding it in its own plan.
+func NewHousehold(plans []Plan) (Household, error) {
+	var h Household
+	people := map[string]bool{}
+	for i, p := range plans {
+		if i > 0 && !p.Date.Equal(h.Date) {
+			return Household{}, fmt.Errorf("%w: %s and %s", ErrMixedDates, h.Date.Format(dateLayout), p.Date.Format(dateLayout))
+		}
+		h.Date = p.Date
+		person := householdName(p.Profile)
+		if person == "" {
+			return Household{}, fmt.Errorf("plan %d has no person or variant", i+1)
+		}
+		if people[person] {
+			return Household{}, fmt.Errorf("several plans for %s", person)
+		}
+		people[person] = true
+		h.Members = append(h.Members, p.Profile)
+
+		last := -1
+		for _, m := range p.Meals {
+			idx := h.mealIndex(m.Name)
+			if idx < 0 {
+				idx = last + 1
+				h.Meals = append(h.Meals[:idx], append([]HouseholdMeal{{Name: m.Name}}, h.Meals[idx:]...)...)
+			}
+			last = idx
+			h.Meals[idx].add(m.Dishes, person)
+		}
+	}
+	return h, nil
+}
+
+// mealIndex returns the index of the meal with the name, or -1
+func (h Household) mealIndex(name string) int {
+	for i, m := range h.Meals {
+		if CanonicalName(m.Name) == CanonicalName(name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// add adds the dishes eaten by a person to the meal. A dish listed twice for the person
+// lists them once, so it is not bought twice.
+func (m *HouseholdMeal) add(dishes []Dish, person string) {
+	for _, d := range dishes {
+		found := false
+		for i := range m.Dishes {
+			if CanonicalName(m.Dishes[i].Name) == CanonicalName(d.Name) {
+				if !slices.Contains(m.Dishes[i].People, person) {
+					m.Dishes[i].People = append(m.Dishes[i].People, person)
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			m.Dishes = append(m.Dishes, HouseholdDish{Dish: d, People: []string{person}})
+		}
+	}
+}
+
+// Shopping returns the ingredients of all dishes, without their composition, with the number
+// of servings they are needed for, sorted by name. An ingredient listed twice in a dish counts once.
+func (h Household) Shopping() []ShoppingItem {
+	items := map[string]*ShoppingItem{}
+	for _, m := range h.Meals {
+		for _, d := range m.Dishes {
+			seen := map[string]bool{}
+			for _, ing := range ParseIngredients(d.Ingredients) {
+				key := CanonicalName(ing.Name)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				if items[key] == nil {
+					items[key] = &ShoppingItem{Name: ing.Name}
+				}
+				items[key].Servings += len(d.People)
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	shopping := make([]ShoppingItem, 0, len(keys))
+	for _, key := range keys {
+		shopping = append(shopping, *items[key])
+	}
+	return shopping
+}
+
+// Allergens returns the allergens of the dishes, in catalog order, with the people eating them
+// in the order of the members
+func (h Household) Allergens() []AllergenExposure {
+	exposed := map[string]map[string]bool{}
+	for _, m := range h.Meals {
+		for _, d := range m.Dishes {
+			for _, allergen := range d.Allergens() {
+				if exposed[allergen] == nil {
+					exposed[allergen] = map[string]bool{}
+				}
+				for _, person := range d.People {
+					exposed[allergen][person] = true
+				}
+			}
+		}
+	}
+
+	var exposures []AllergenExposure
+	for _, allergen := range Allergens {
+		people := exposed[allergen.Name]
+		if len(people) == 0 {
+			continue
+		}
+		exposure := AllergenExposure{Allergen: allergen.Name}
+		for _, member := range h.Members {
+			if name := householdName(member); people[name] {
+				exposure.People = append(exposure.People, name)
+			}
+		}
+		exposures = append(exposures, exposure)
+	}
+	return exposures
+}
+
+// Plan returns the merged plan with the members noted on the day and the people eating every dish noted on it
+func (h Household) Plan(labels Labels) Plan {
+	members := make([]string, 0, len(h.Members))
+	for _, member := range h.Members {
+		members = append(members, memberName(member))
+	}
+	plan := Plan{Date: h.Date, Annotations: []string{labels.Household + ": " + strings.Join(members, ", ")}}
+	for _, m := range h.Meals {
+		pm := Meal{Name: m.Name}
+		for _, d := range m.Dishes {
+			dish := d.Dish
+			dish.Annotations = append(append([]string(nil), dish.Annotations...), labels.People+": "+strings.Join(d.People, ", "))
+			pm.Dishes = append(pm.Dishes, dish)
+		}
+		plan.Meals = append(plan.Meals, pm)
+	}
+	return plan
+}
+
+// householdName returns the name a member is known by in the household: the person, or the variant
+// of a plan without one, e.g. "wege 1500 kcal"
+func householdName(p Profile) string {
+	if p.Person != "" {
+		return p.Person
+	}
+	return p.String()
+}
+
+// memberName returns the person with the variant, e.g. "Anna (wege 1500 kcal)"
+func memberName(p Profile) string {
+	if p.Person == "" {
+		return householdName(p)
+	}
+	if variant := (Profile{Variant: p.Variant, Calories: p.Calories}).String(); variant != "" {
+		return p.Person + " (" + variant + ")"
+	}
+	return p.Person
+}
+
+// summarySection is a titled list shown after the meals
+type summarySection struct {
+	Title string
+	Items []string
+}
+
+// summary returns the shopping list and the allergens with the people eating them
+func (h Household) summary(labels Labels) []summarySection {
+	shopping := summarySection{Title: labels.Shopping}
+	for _, item := range h.Shopping() {
+		text := item.Name
+		if item.Servings > 1 {
+			text += " ×" + strconv.Itoa(item.Servings)
+		}
+		shopping.Items = append(shopping.Items, text)
+	}
+	allergens := summarySection{Title: labels.Allergens}
+	for _, exposure := range h.Allergens() {
+		allergens.Items = append(allergens.Items, labels.AllergenName(exposure.Allergen)+": "+strings.Join(exposure.People, ", "))
+	}
+
+	var sections []summarySection
+	for _, s := range []summarySection{shopping, allergens} {
+		if len(s.Items) > 0 {
+			sections = append(sections, s)
+		}
+	}
+	return sections
+}
+
+// title returns the title of the household menu, e.g. "Jadłospis 01.01.2026 – Anna, Piotr"
+func (h Household) title(labels Labels) string {
+	people := make([]string, 0, len(h.Members))
+	for _, member := range h.Members {
+		people = append(people, householdName(member))
+	}
+	return labels.Title(h.Date) + " – " + strings.Join(people, ", ")
+}
+
+// FormatToMarkdownIn converts the household menu to Markdown, followed by the shopping list and the allergens
+func (h Household) FormatToMarkdownIn(labels Labels) string {
+	plan := h.Plan(labels)
+	var sb strings.Builder
+	sb.WriteString(plan.FormatToMarkdownIn(labels))
+	for _, s := range h.summary(labels) {
+		sb.WriteString("# " + s.Title + "\n\n")
+		for _, item := range s.Items {
+			sb.WriteString("- " + item + "\n")
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// FormatToHTMLIn converts the household menu to a self-contained HTML page, followed by the shopping list
+// and the allergens
+func (h Household) FormatToHTMLIn(labels Labels) (string, error) {
+	plan := h.Plan(labels)
+	return plan.formatToHTML(h.title(labels), labels, h.summary(labels))
+}
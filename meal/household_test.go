@@ -0,0 +1,157 @@
+package meal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func householdPlans() []Plan {
+	date := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []Plan{
+		{
+			Date:    date,
+			Profile: Profile{Person: "Anna", Variant: "wege", Calories: 1500},
+			Meals: []Meal{
+				{Name: "Śniadanie", Dishes: []Dish{{Name: "Owsianka", Ingredients: []string{"Płatki owsiane", "Mleko", "Mleko"}}}},
+				{Name: "Obiad", Dishes: []Dish{{Name: "Dal z soczewicy", Ingredients: []string{"Soczewica czerwona", "Mleczko kokosowe"}}}},
+			},
+		},
+		{
+			Date:    date,
+			Profile: Profile{Person: "Piotr"},
+			Meals: []Meal{
+				{Name: "Śniadanie", Dishes: []Dish{{Name: "owsianka", Ingredients: []string{"Płatki owsiane", "Mleko"}}}},
+				{Name: "II śniadanie", Dishes: []Dish{{Name: "Kanapka z jajkiem", Ingredients: []string{"Chleb żytni", "Jaja kurze"}}}},
+				{Name: "Obiad", Dishes: []Dish{{Name: "Gulasz wołowy", Ingredients: []string{"Wołowina", "Cebula"}}}},
+			},
+		},
+	}
+}
+
+func TestNewHousehold(t *testing.T) {
+	t.Run("meals merged in order", func(t *testing.T) {
+		h, err := NewHousehold(householdPlans())
+
+		require.NoError(t, err)
+		require.Len(t, h.Meals, 3)
+		assert.Equal(t, "Śniadanie", h.Meals[0].Name)
+		assert.Equal(t, "II śniadanie", h.Meals[1].Name)
+		assert.Equal(t, "Obiad", h.Meals[2].Name)
+	})
+
+	t.Run("shared dishes listed once", func(t *testing.T) {
+		h, err := NewHousehold(householdPlans())
+
+		require.NoError(t, err)
+		require.Len(t, h.Meals[0].Dishes, 1)
+		assert.Equal(t, "Owsianka", h.Meals[0].Dishes[0].Name)
+		assert.Equal(t, []string{"Anna", "Piotr"}, h.Meals[0].Dishes[0].People)
+		require.Len(t, h.Meals[2].Dishes, 2)
+		assert.Equal(t, []string{"Anna"}, h.Meals[2].Dishes[0].People)
+		assert.Equal(t, []string{"Piotr"}, h.Meals[2].Dishes[1].People)
+	})
+
+	t.Run("dish listed twice for a person", func(t *testing.T) {
+		plans := householdPlans()
+		plans[1].Meals = append(plans[1].Meals, Meal{Name: "śniadanie", Dishes: []Dish{{Name: "Owsianka"}}})
+
+		h, err := NewHousehold(plans)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Anna", "Piotr"}, h.Meals[0].Dishes[0].People)
+		assert.Contains(t, h.Shopping(), ShoppingItem{Name: "Płatki owsiane", Servings: 2})
+	})
+
+	t.Run("different days", func(t *testing.T) {
+		plans := householdPlans()
+		plans[1].Date = plans[1].Date.AddDate(0, 0, 1)
+
+		_, err := NewHousehold(plans)
+
+		assert.ErrorIs(t, err, ErrMixedDates)
+	})
+
+	t.Run("plan without a person", func(t *testing.T) {
+		plans := householdPlans()
+		plans[0].Profile.Person = ""
+		plans[1].Profile = Profile{Variant: "sport", Calories: 2500}
+
+		h, err := NewHousehold(plans)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"wege 1500 kcal", "sport 2500 kcal"}, h.Meals[0].Dishes[0].People)
+		assert.Contains(t, h.FormatToMarkdownIn(PolishLabels), "> Domownicy: wege 1500 kcal, sport 2500 kcal\n")
+	})
+
+	t.Run("plan without a profile", func(t *testing.T) {
+		plans := householdPlans()
+		plans[1].Profile = Profile{}
+
+		_, err := NewHousehold(plans)
+
+		assert.EqualError(t, err, "plan 2 has no person or variant")
+	})
+
+	t.Run("several plans for a person", func(t *testing.T) {
+		plans := householdPlans()
+		plans[1].Profile.Person = "Anna"
+
+		_, err := NewHousehold(plans)
+
+		assert.EqualError(t, err, "several plans for Anna")
+	})
+}
+
+func TestHouseholdSummary(t *testing.T) {
+	h, err := NewHousehold(householdPlans())
+	require.NoError(t, err)
+
+	t.Run("shopping", func(t *testing.T) {
+		assert.Equal(t, []ShoppingItem{
+			{Name: "Cebula", Servings: 1},
+			{Name: "Chleb żytni", Servings: 1},
+			{Name: "Jaja kurze", Servings: 1},
+			{Name: "Mleczko kokosowe", Servings: 1},
+			{Name: "Mleko", Servings: 2},
+			{Name: "Płatki owsiane", Servings: 2},
+			{Name: "Soczewica czerwona", Servings: 1},
+			{Name: "Wołowina", Servings: 1},
+		}, h.Shopping())
+	})
+
+	t.Run("allergens", func(t *testing.T) {
+		assert.Equal(t, []AllergenExposure{
+			{Allergen: "gluten", People: []string{"Anna", "Piotr"}},
+			{Allergen: "jaja", People: []string{"Piotr"}},
+			{Allergen: "mleko", People: []string{"Anna", "Piotr"}},
+		}, h.Allergens())
+	})
+}
+
+func TestHouseholdFormat(t *testing.T) {
+	h, err := NewHousehold(householdPlans())
+	require.NoError(t, err)
+
+	t.Run("markdown", func(t *testing.T) {
+		result := h.FormatToMarkdownIn(PolishLabels)
+
+		assert.Contains(t, result, "> Domownicy: Anna (wege 1500 kcal), Piotr\n\n# Śniadanie\n\n## Owsianka\n> Dla: Anna, Piotr\n")
+		assert.Contains(t, result, "## Gulasz wołowy\n> Dla: Piotr\n")
+		assert.Contains(t, result, "# Lista zakupów\n\n- Cebula\n- Chleb żytni\n")
+		assert.Contains(t, result, "- Płatki owsiane ×2\n")
+		assert.Contains(t, result, "# Alergeny\n\n- gluten: Anna, Piotr\n- jaja: Piotr\n")
+	})
+
+	t.Run("html", func(t *testing.T) {
+		result, err := h.FormatToHTMLIn(PolishLabels)
+
+		require.NoError(t, err)
+		assert.Contains(t, result, "<title>Jadłospis 01.01.2026 – Anna, Piotr</title>")
+		assert.Contains(t, result, "<h3>Owsianka</h3>\n<p class=\"note\">Dla: Anna, Piotr</p>")
+		assert.Contains(t, result, "<section class=\"summary\">\n<h2>Lista zakupów</h2>\n<ul>\n<li>Cebula</li>")
+		assert.Contains(t, result, "<li>jaja: Piotr</li>")
+	})
+}
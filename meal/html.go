@@ -0,0 +1,52 @@
+package meal
+
+import (
+	"embed"
+	"html/template"
+	"strings"
+)
+
+//go:embed templates
+var templates embed.FS
+
+var htmlTemplate = template.Must(template.ParseFS(templates, "templates/plan.html"))
+
+// FormatToHTML converts a meal Plan to a self-contained HTML page with embedded screen and print styles
+func (p *Plan) FormatToHTML() (string, error) {
+	return p.FormatToHTMLIn(PolishLabels)
+}
+
+// FormatToHTMLIn converts a meal Plan to a self-contained HTML page with labels in the given language
+func (p *Plan) FormatToHTMLIn(labels Labels) (string, error) {
+	return p.formatToHTML(labels.PlanTitle(*p), labels, nil)
+}
+
+// formatToHTML converts a meal Plan to a self-contained HTML page with the title, followed by the summary sections
+func (p *Plan) formatToHTML(title string, labels Labels, sections []summarySection) (string, error) {
+	jsonLD, err := p.FormatToJSONLDIn(labels)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	err = htmlTemplate.Execute(&sb, struct {
+		Title       string
+		Labels      Labels
+		Annotations []string
+		Meals       []Meal
+		Sections    []summarySection
+		JSONLD      template.JS
+	}{
+		Title:       title,
+		Labels:      labels,
+		Annotations: p.Annotations,
+		Meals:       p.Meals,
+		Sections:    sections,
+		// FormatToJSONLDIn escapes <, > and &, so the menu cannot close the script element
+		JSONLD: template.JS(jsonLD),
+	})
+	if err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
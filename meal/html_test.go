@@ -0,0 +1,52 @@
+package meal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatToHTML(t *testing.T) {
+	plan := Plan{
+		Date: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		Meals: []Meal{
+			{Name: "Śniadanie", Dishes: []Dish{
+				{Name: "Jajecznica", Ingredients: []string{"Jajka 2 szt.", "Masło 10g"}, Tags: []string{"wege", "nowość"}},
+				{Name: "Herbata", Instructions: []string{"Podawać ciepłą"}},
+			}},
+			{Name: "Obiad", Dishes: []Dish{
+				{Name: "Pierogi <ruskie> & surówka", Ingredients: []string{"Mąka pszenna"}},
+			}},
+		},
+	}
+
+	result, err := plan.FormatToHTML()
+
+	assert.NoError(t, err)
+	assert.Contains(t, result, "<title>Jadłospis 01.01.2026</title>")
+	assert.Contains(t, result, "grid-template-columns: repeat(2, minmax(12rem, 1fr))")
+	assert.Contains(t, result, "<h2>Śniadanie</h2>")
+	assert.Contains(t, result, "<summary>Składniki (2)</summary>\n<ul>\n<li>Jajka 2 szt.</li>\n<li>Masło 10g</li>\n</ul>")
+	assert.Contains(t, result, "<h3>Jajecznica</h3>\n<p class=\"tags\"><span class=\"tag\">wege</span><span class=\"tag\">nowość</span></p>")
+	assert.Contains(t, result, "\"keywords\": \"wege, nowość\"")
+	assert.Contains(t, result, "<h3>Herbata</h3>\n<p class=\"instruction\"><strong>Wskazówki:</strong> Podawać ciepłą</p>")
+	assert.Contains(t, result, "\"recipeInstructions\": [\n            \"Podawać ciepłą\"\n          ]")
+	assert.Contains(t, result, "<h3>Pierogi &lt;ruskie&gt; &amp; surówka</h3>")
+	assert.Contains(t, result, "@media print")
+	assert.Contains(t, result, "  .meals { grid-template-columns: repeat(2, 1fr); gap: 3mm; }")
+	assert.NotContains(t, result, "<link")
+	assert.NotContains(t, result, "<script src")
+	assert.Contains(t, result, "<script type=\"application/ld+json\">\n{\n  \"@context\": \"https://schema.org\",")
+	assert.Contains(t, result, "\"name\": \"Pierogi \\u003cruskie\\u003e \\u0026 surówka\"")
+
+	t.Run("plan without meals", func(t *testing.T) {
+		empty := Plan{Date: plan.Date}
+		result, err := empty.FormatToHTML()
+
+		assert.NoError(t, err)
+		assert.NotContains(t, result, "repeat(")
+		assert.Contains(t, result, ".meals { display: grid; gap: 1rem; align-items: start; }")
+		assert.Contains(t, result, "  .meals { gap: 3mm; }")
+	})
+}
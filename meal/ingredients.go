@@ -2,6 +2,7 @@ package meal
 
 import (
 	"regexp"
+	"strconv"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -160,3 +161,126 @@ func utf8SentenceCase(s string) string {
 	caser := cases.Title(language.Polish)
 	return caser.String(string(r)) + lower[size:]
 }
+
+// Ingredient is a single processed ingredient split into its name and the composition given in parentheses.
+// Percentage is only filled in for components, where it states the share of the component in its parent;
+// on top-level ingredients a trailing percentage (e.g. "Śmietanka 15%") is part of the product name.
+type Ingredient struct {
+	Text       string       `json:"text" jsonschema:"Ingredient as printed in the menu"`
+	Name       string       `json:"name" jsonschema:"Ingredient name without its composition"`
+	Percentage float64      `json:"percentage,omitempty" jsonschema:"Share of the component in its parent ingredient, in percent"`
+	Components []Ingredient `json:"components,omitempty" jsonschema:"Ingredients listed in parentheses after the name"`
+}
+
+var componentPercentageRegexp = regexp.MustCompile(`^(.*?)\s*(\d+(?:[.,]\d+)?)\s*%$`)
+
+// ParseIngredient splits a processed ingredient string into a structured Ingredient
+func ParseIngredient(text string) Ingredient {
+	return parseIngredient(strings.TrimSpace(text), false)
+}
+
+// ParseIngredients parses every processed ingredient string of a dish
+func ParseIngredients(ingredients []string) []Ingredient {
+	parsed := make([]Ingredient, 0, len(ingredients))
+	for _, ing := range ingredients {
+		parsed = append(parsed, ParseIngredient(ing))
+	}
+	return parsed
+}
+
+func parseIngredient(text string, isComponent bool) Ingredient {
+	ing := Ingredient{Text: text}
+
+	var name strings.Builder
+	var groups []string
+	var group strings.Builder
+	depth := 0
+	for _, r := range text {
+		switch {
+		case r == '(':
+			if depth > 0 {
+				group.WriteRune(r)
+			}
+			depth++
+		case r == ')' && depth > 0:
+			depth--
+			if depth > 0 {
+				group.WriteRune(r)
+			} else {
+				groups = append(groups, group.String())
+				group.Reset()
+			}
+		case r == ')':
+			// Stray closing parenthesis left over from the provider's export, ignore it
+		case depth > 0:
+			group.WriteRune(r)
+		default:
+			name.WriteRune(r)
+		}
+	}
+	if depth > 0 {
+		groups = append(groups, group.String())
+	}
+
+	ing.Name = strings.Join(strings.Fields(name.String()), " ")
+	if isComponent {
+		if m := componentPercentageRegexp.FindStringSubmatch(ing.Name); m != nil && m[1] != "" {
+			ing.Name = m[1]
+			ing.Percentage = parsePercentage(m[2])
+		}
+	}
+
+	for _, g := range groups {
+		g = strings.TrimSpace(g)
+		if m := componentPercentageRegexp.FindStringSubmatch(g); isComponent && m != nil && m[1] == "" {
+			ing.Percentage = parsePercentage(m[2])
+			continue
+		}
+		for _, part := range splitTopLevel(g) {
+			if part = strings.TrimSpace(part); part != "" {
+				ing.Components = append(ing.Components, parseIngredient(part, true))
+			}
+		}
+	}
+
+	return ing
+}
+
+// splitTopLevel splits s on commas that are not inside parentheses
+func splitTopLevel(s string) []string {
+	var parts []string
+	depth := 0
+	start := 0
+	for i, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			if depth > 0 {
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// parsePercentage converts a percentage number with a decimal point or comma to float64
+func parsePercentage(s string) float64 {
+	f, err := strconv.ParseFloat(strings.Replace(s, ",", ".", 1), 64)
+	if err != nil {
+		return 0
+	}
+	return f
+}
+
+// CanonicalName normalises an ingredient or meal name for dictionary lookups: lowercased,
+// with single spaces and without trailing markers such as "*" or ":"
+func CanonicalName(name string) string {
+	name = cases.Lower(language.Polish).String(strings.Join(strings.Fields(name), " "))
+	return strings.TrimRight(name, " *:")
+}
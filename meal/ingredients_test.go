@@ -32,3 +32,48 @@ func TestProcessIngredients(t *testing.T) {
 		assert.Equal(t, expected, ProcessIngredients(ingredients))
 	})
 }
+
+func TestParseIngredient(t *testing.T) {
+	t.Run("simple ingredient", func(t *testing.T) {
+		expected := Ingredient{Text: "Śmietanka 15%", Name: "Śmietanka 15%"}
+		assert.Equal(t, expected, ParseIngredient("Śmietanka 15%"))
+	})
+
+	t.Run("composite ingredient", func(t *testing.T) {
+		expected := Ingredient{
+			Text: "Bagietka de tradition (mąka pszenna, woda, sól)",
+			Name: "Bagietka de tradition",
+			Components: []Ingredient{
+				{Text: "mąka pszenna", Name: "mąka pszenna"},
+				{Text: "woda", Name: "woda"},
+				{Text: "sól", Name: "sól"},
+			},
+		}
+		assert.Equal(t, expected, ParseIngredient("Bagietka de tradition (mąka pszenna, woda, sól)"))
+	})
+
+	t.Run("nested components with percentages", func(t *testing.T) {
+		expected := Ingredient{
+			Text: "Wanilia (perły wanilii (62.5%), naturalny koncentrat waniliowy 37.5%))",
+			Name: "Wanilia",
+			Components: []Ingredient{
+				{Text: "perły wanilii (62.5%)", Name: "perły wanilii", Percentage: 62.5},
+				{Text: "naturalny koncentrat waniliowy 37.5%", Name: "naturalny koncentrat waniliowy", Percentage: 37.5},
+			},
+		}
+		assert.Equal(t, expected, ParseIngredient("Wanilia (perły wanilii (62.5%), naturalny koncentrat waniliowy 37.5%))"))
+	})
+
+	t.Run("several parenthesis groups", func(t *testing.T) {
+		result := ParseIngredient("Mix bułek (hotelowy, wykwintny) (mąka (pszenna, żytnia), sezam)")
+		assert.Equal(t, "Mix bułek", result.Name)
+		assert.Len(t, result.Components, 4)
+		assert.Equal(t, "mąka", result.Components[2].Name)
+		assert.Len(t, result.Components[2].Components, 2)
+	})
+
+	t.Run("stray closing parenthesis", func(t *testing.T) {
+		expected := Ingredient{Text: "Kwas askorbinowy)", Name: "Kwas askorbinowy"}
+		assert.Equal(t, expected, ParseIngredient("Kwas askorbinowy)"))
+	})
+}
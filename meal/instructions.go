@@ -0,0 +1,83 @@
+package meal
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// instructionVerbs start the words of serving and heating instructions, e.g. "lekko podgrzać";
+// they are infinitives used as imperatives, so participles such as "podawany" are not matched
+var instructionVerbs = []string{
+	"podgrzać", "odgrzać", "ogrzać", "zagrzać", "schłodzić", "ostudzić", "rozmrozić",
+	"podawać", "podać", "wymieszać", "polać", "posypać", "skropić", "zjeść", "spożyć",
+}
+
+// cautionWord starts a caution about the dish, e.g. "Uwaga! Orzechy mogą zawierać łupiny."
+// Cautions are not instructions, so they stay in the name even when they have an instruction verb.
+const cautionWord = "uwaga"
+
+var (
+	// parenthesisRegexp matches a clause in parentheses
+	parenthesisRegexp = regexp.MustCompile(`\s*\(([^()]*)\)`)
+	// trailingClauseRegexp matches the last clause of a name, after a comma or a dash
+	trailingClauseRegexp = regexp.MustCompile(`\s*(?:,|\s-|\s–)\s*([^,()–-]+)$`)
+)
+
+// SplitInstructions separates serving and heating instructions from a dish name, e.g.
+// "Mini drożdżówki ze śliwkami (lekko podgrzać)" is split into the title "Mini drożdżówki ze śliwkami"
+// and the instruction "Lekko podgrzać". Instructions are clauses in parentheses, or the last clause
+// after a comma or a dash, with a verb of instructionVerbs.
+func SplitInstructions(name string) (string, []string) {
+	var instructions []string
+	title := parenthesisRegexp.ReplaceAllStringFunc(name, func(group string) string {
+		clause := strings.TrimSpace(parenthesisRegexp.FindStringSubmatch(group)[1])
+		if !isInstruction(clause) || isCaution(clause) {
+			return group
+		}
+		instructions = append(instructions, capitalize(clause))
+		return ""
+	})
+	if m := trailingClauseRegexp.FindStringSubmatchIndex(title); m != nil {
+		if clause := strings.TrimSpace(title[m[2]:m[3]]); isInstruction(clause) && !isCaution(clause) {
+			instructions = append(instructions, capitalize(clause))
+			title = title[:m[0]]
+		}
+	}
+	return strings.TrimSpace(title), instructions
+}
+
+// isInstruction reports whether a clause has one of the instruction verbs
+func isInstruction(clause string) bool {
+	for _, w := range words(clause) {
+		for _, verb := range instructionVerbs {
+			if w == verb {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isCaution reports whether a clause is a caution
+func isCaution(clause string) bool {
+	ws := words(clause)
+	return len(ws) > 0 && ws[0] == cautionWord
+}
+
+// ExtractInstructions moves the instructions found in the dish name to Instructions
+func (d *Dish) ExtractInstructions() {
+	title, instructions := SplitInstructions(d.Name)
+	if len(instructions) == 0 || title == "" {
+		return
+	}
+	d.Name = title
+	d.Instructions = append(d.Instructions, instructions...)
+}
+
+// capitalize upper-cases the first letter, leaving the rest as written
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
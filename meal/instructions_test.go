@@ -0,0 +1,90 @@
+package meal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitInstructions(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		title        string
+		instructions []string
+	}{
+		{
+			name:         "instruction in parentheses",
+			input:        "Mini drożdżówki ze śliwkami (lekko podgrzać)",
+			title:        "Mini drożdżówki ze śliwkami",
+			instructions: []string{"Lekko podgrzać"},
+		},
+		{
+			name:         "instruction in the middle of the name",
+			input:        "Brioche (lekko podgrzać) z masłem orzechowym",
+			title:        "Brioche z masłem orzechowym",
+			instructions: []string{"Lekko podgrzać"},
+		},
+		{
+			name:  "caution in parentheses is not an instruction",
+			input: "Tortilla z kozim serem i orzechami włoskimi (uwaga, orzechy mogą zawierać łupiny)",
+			title: "Tortilla z kozim serem i orzechami włoskimi (uwaga, orzechy mogą zawierać łupiny)",
+		},
+		{
+			name:  "caution sentence is not an instruction",
+			input: "Carpaccio z buraka z serem kozim. Uwaga! Orzechy mogą zawierać łupiny.",
+			title: "Carpaccio z buraka z serem kozim. Uwaga! Orzechy mogą zawierać łupiny.",
+		},
+		{
+			name:         "caution with an instruction verb",
+			input:        "Jogurt z granolą (uwaga, spożyć do końca dnia) (podawać schłodzony)",
+			title:        "Jogurt z granolą (uwaga, spożyć do końca dnia)",
+			instructions: []string{"Podawać schłodzony"},
+		},
+		{
+			name:         "trailing clause",
+			input:        "Zupa krem z dyni, podgrzać przed podaniem",
+			title:        "Zupa krem z dyni",
+			instructions: []string{"Podgrzać przed podaniem"},
+		},
+		{
+			name:  "participle is not an instruction",
+			input: "Pasztet z kaczką, podawany z ogórkiem i pieczywem",
+			title: "Pasztet z kaczką, podawany z ogórkiem i pieczywem",
+		},
+		{
+			name:  "description in parentheses",
+			input: "Sernik na zimno (bez pieczenia) z malinami",
+			title: "Sernik na zimno (bez pieczenia) z malinami",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, instructions := SplitInstructions(tt.input)
+
+			assert.Equal(t, tt.title, title)
+			assert.Equal(t, tt.instructions, instructions)
+		})
+	}
+}
+
+func TestDishExtractInstructions(t *testing.T) {
+	t.Run("instructions are appended", func(t *testing.T) {
+		dish := Dish{Name: "Pierogi ruskie (odgrzać na patelni)", Instructions: []string{"Podawać ze śmietaną"}}
+
+		dish.ExtractInstructions()
+
+		assert.Equal(t, "Pierogi ruskie", dish.Name)
+		assert.Equal(t, []string{"Podawać ze śmietaną", "Odgrzać na patelni"}, dish.Instructions)
+	})
+
+	t.Run("name of only an instruction is kept", func(t *testing.T) {
+		dish := Dish{Name: "(podgrzać)"}
+
+		dish.ExtractInstructions()
+
+		assert.Equal(t, "(podgrzać)", dish.Name)
+		assert.Empty(t, dish.Instructions)
+	})
+}
@@ -0,0 +1,109 @@
+package meal
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// schemaOrgContext is the JSON-LD context of schema.org vocabulary
+const schemaOrgContext = "https://schema.org"
+
+// MenuLD is a schema.org Menu in JSON-LD
+type MenuLD struct {
+	Context      string          `json:"@context"`
+	Type         string          `json:"@type"`
+	Name         string          `json:"name"`
+	InLanguage   string          `json:"inLanguage,omitempty"`
+	Date         string          `json:"temporalCoverage,omitempty"`
+	MenuSections []MenuSectionLD `json:"hasMenuSection"`
+}
+
+// MenuSectionLD is a schema.org MenuSection, one per meal
+type MenuSectionLD struct {
+	Type      string       `json:"@type"`
+	Name      string       `json:"name"`
+	MenuItems []MenuItemLD `json:"hasMenuItem"`
+}
+
+// MenuItemLD is a dish typed both as a schema.org MenuItem and a Recipe, as only recipes
+// have recipeIngredient and recipe managers import the Recipe type
+type MenuItemLD struct {
+	Type             []string `json:"@type"`
+	Name             string   `json:"name"`
+	RecipeCategory   string   `json:"recipeCategory,omitempty"`
+	RecipeIngredient []string `json:"recipeIngredient,omitempty"`
+	Keywords         string   `json:"keywords,omitempty"`
+	// RecipeInstructions are the serving and heating instructions of the dish
+	RecipeInstructions []string `json:"recipeInstructions,omitempty"`
+	SuitableForDiet    []string `json:"suitableForDiet,omitempty"`
+}
+
+// restrictedDiets maps diets of the catalog to schema.org RestrictedDiet values;
+// the others have no counterpart
+var restrictedDiets = map[string]string{
+	"vegetarian":   "https://schema.org/VegetarianDiet",
+	"vegan":        "https://schema.org/VeganDiet",
+	"gluten-free":  "https://schema.org/GlutenFreeDiet",
+	"lactose-free": "https://schema.org/LowLactoseDiet",
+}
+
+// ToMenuLD converts a meal to a MenuSection
+func (m Meal) ToMenuLD() MenuSectionLD {
+	section := MenuSectionLD{Type: "MenuSection", Name: m.Name, MenuItems: make([]MenuItemLD, 0, len(m.Dishes))}
+	for _, d := range m.Dishes {
+		item := d.ToMenuLD()
+		item.RecipeCategory = m.Name
+		section.MenuItems = append(section.MenuItems, item)
+	}
+	return section
+}
+
+// ToMenuLD converts a dish to a MenuItem with its ingredients in the order of the menu
+func (d Dish) ToMenuLD() MenuItemLD {
+	item := MenuItemLD{
+		Type:               []string{"MenuItem", "Recipe"},
+		Name:               d.Name,
+		RecipeIngredient:   d.Ingredients,
+		Keywords:           strings.Join(d.Tags, ", "),
+		RecipeInstructions: d.Instructions,
+	}
+	for _, diet := range d.Diets() {
+		if restricted, ok := restrictedDiets[diet]; ok {
+			item.SuitableForDiet = append(item.SuitableForDiet, restricted)
+		}
+	}
+	return item
+}
+
+// ToMenuLD converts the Plan to a schema.org Menu titled with the labels of a language
+func (p *Plan) ToMenuLD(labels Labels) MenuLD {
+	menu := MenuLD{
+		Context:      schemaOrgContext,
+		Type:         "Menu",
+		Name:         labels.PlanTitle(*p),
+		InLanguage:   labels.Lang,
+		MenuSections: make([]MenuSectionLD, 0, len(p.Meals)),
+	}
+	if !p.Date.IsZero() {
+		menu.Date = p.Date.Format(dateLayout)
+	}
+	for _, m := range p.Meals {
+		menu.MenuSections = append(menu.MenuSections, m.ToMenuLD())
+	}
+	return menu
+}
+
+// FormatToJSONLD converts a meal Plan to a schema.org Menu in JSON-LD
+func (p *Plan) FormatToJSONLD() (string, error) {
+	return p.FormatToJSONLDIn(PolishLabels)
+}
+
+// FormatToJSONLDIn converts a meal Plan to a schema.org Menu in JSON-LD with labels in the given language.
+// Characters significant in HTML are escaped, so the result can be embedded in a script element.
+func (p *Plan) FormatToJSONLDIn(labels Labels) (string, error) {
+	data, err := json.MarshalIndent(p.ToMenuLD(labels), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
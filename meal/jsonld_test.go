@@ -0,0 +1,73 @@
+package meal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatToJSONLD(t *testing.T) {
+	t.Run("menu with sections and items", func(t *testing.T) {
+		plan := Plan{
+			Date: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			Meals: []Meal{{Name: "Obiad", Dishes: []Dish{
+				{Name: "Zupa", Ingredients: []string{"Woda", "Marchew"}},
+				{Name: "Herbata"},
+			}}},
+		}
+		expected := `{
+  "@context": "https://schema.org",
+  "@type": "Menu",
+  "name": "Jadłospis 01.01.2026",
+  "inLanguage": "pl",
+  "temporalCoverage": "2026-01-01",
+  "hasMenuSection": [
+    {
+      "@type": "MenuSection",
+      "name": "Obiad",
+      "hasMenuItem": [
+        {
+          "@type": [
+            "MenuItem",
+            "Recipe"
+          ],
+          "name": "Zupa",
+          "recipeCategory": "Obiad",
+          "recipeIngredient": [
+            "Woda",
+            "Marchew"
+          ],
+          "suitableForDiet": [
+            "https://schema.org/VegetarianDiet",
+            "https://schema.org/VeganDiet",
+            "https://schema.org/GlutenFreeDiet",
+            "https://schema.org/LowLactoseDiet"
+          ]
+        },
+        {
+          "@type": [
+            "MenuItem",
+            "Recipe"
+          ],
+          "name": "Herbata",
+          "recipeCategory": "Obiad"
+        }
+      ]
+    }
+  ]
+}
+`
+		result, err := plan.FormatToJSONLD()
+		require.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("empty plan", func(t *testing.T) {
+		result, err := (&Plan{}).FormatToJSONLD()
+		require.NoError(t, err)
+		assert.Contains(t, result, `"hasMenuSection": []`)
+		assert.NotContains(t, result, "temporalCoverage")
+	})
+}
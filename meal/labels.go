@@ -0,0 +1,144 @@
+package meal
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Labels are the fixed texts of formatted output in one language
+type Labels struct {
+	Lang        string `json:"lang"`
+	Menu        string `json:"menu"`
+	Ingredients string `json:"ingredients"`
+	Allergens   string `json:"allergens"`
+	Tags        string `json:"tags"`
+	// Instructions is the label of the serving and heating instructions of a dish
+	Instructions string `json:"instructions"`
+	Changes      string `json:"changes"`
+	Additives    string `json:"additives"`
+	Processing   string `json:"processing"`
+	Diets        string `json:"diets"`
+	// Household introduces the members of a household menu, People the people eating a dish
+	// and Shopping the shopping list of the household
+	Household string `json:"household"`
+	People    string `json:"people"`
+	Shopping  string `json:"shopping"`
+	// MenuFor introduces the date of a menu heading, e.g. "Jadłospis na 1 stycznia 2026"
+	MenuFor string `json:"menuFor"`
+	// IngredientForms are the forms of "ingredient" after a number: one, few and many, as in
+	// "1 składnik", "2 składniki" and "5 składników"
+	IngredientForms [3]string `json:"ingredientForms"`
+	// DietNames are the names of the diets of the catalog in the language
+	DietNames map[string]string `json:"dietNames"`
+	// AllergenNames are the names of the allergens of the catalog, which are Polish, in the language
+	AllergenNames map[string]string `json:"allergenNames"`
+	Weekdays      [7]string         `json:"weekdays"`
+	Months        [12]string        `json:"months"`
+	DateLayout    string            `json:"dateLayout"`
+	// LongDate is a pattern with {day}, {month} and {year} placeholders
+	LongDate string `json:"longDate"`
+}
+
+// PolishLabels are the labels of the default output
+var PolishLabels = Labels{
+	Lang:            "pl",
+	Menu:            "Jadłospis",
+	Ingredients:     "Składniki",
+	Allergens:       "Alergeny",
+	Tags:            "Tagi",
+	Instructions:    "Wskazówki",
+	Changes:         "Zmiany od",
+	Additives:       "Dodatki",
+	Processing:      "Przetworzenie (NOVA)",
+	Diets:           "Diety",
+	Household:       "Domownicy",
+	People:          "Dla",
+	Shopping:        "Lista zakupów",
+	MenuFor:         "Jadłospis na",
+	IngredientForms: [3]string{"składnik", "składniki", "składników"},
+	DietNames: map[string]string{
+		"vegetarian":   "wegetariańska",
+		"pescatarian":  "pescetariańska",
+		"vegan":        "wegańska",
+		"gluten-free":  "bezglutenowa",
+		"lactose-free": "bez laktozy",
+		"nut-free":     "bez orzechów",
+	},
+	Weekdays:   [7]string{"niedziela", "poniedziałek", "wtorek", "środa", "czwartek", "piątek", "sobota"},
+	Months:     [12]string{"stycznia", "lutego", "marca", "kwietnia", "maja", "czerwca", "lipca", "sierpnia", "września", "października", "listopada", "grudnia"},
+	DateLayout: "02.01.2006",
+	LongDate:   "{day} {month} {year}",
+}
+
+// Weekday returns the name of the day of the week, or an empty string for a zero date
+func (l Labels) Weekday(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return l.Weekdays[t.Weekday()]
+}
+
+// FormatDate formats a date with DateLayout, or returns an empty string for a zero date
+func (l Labels) FormatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(l.DateLayout)
+}
+
+// FormatLongDate writes a date out with the month name, e.g. "1 stycznia 2026"
+func (l Labels) FormatLongDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return strings.NewReplacer(
+		"{day}", strconv.Itoa(t.Day()),
+		"{month}", l.Months[t.Month()-1],
+		"{year}", strconv.Itoa(t.Year()),
+	).Replace(l.LongDate)
+}
+
+// DietName returns the name of a diet of the catalog in the language, or the name itself
+// when it has no translation
+func (l Labels) DietName(name string) string {
+	if translated, ok := l.DietNames[name]; ok {
+		return translated
+	}
+	return name
+}
+
+// AllergenName returns the name of an allergen of the catalog in the language, or the name itself
+// when it has no translation
+func (l Labels) AllergenName(name string) string {
+	if translated, ok := l.AllergenNames[name]; ok {
+		return translated
+	}
+	return name
+}
+
+// AllergenNamesOf returns the names of allergens of the catalog in the language
+func (l Labels) AllergenNamesOf(names []string) []string {
+	translated := make([]string, 0, len(names))
+	for _, name := range names {
+		translated = append(translated, l.AllergenName(name))
+	}
+	return translated
+}
+
+// Title returns the menu title for the date, e.g. "Jadłospis 01.01.2026"
+func (l Labels) Title(t time.Time) string {
+	if t.IsZero() {
+		return l.Menu
+	}
+	return l.Menu + " " + l.FormatDate(t)
+}
+
+// PlanTitle returns the menu title for the plan with whose plan it is,
+// e.g. "Jadłospis 01.01.2026 – Anna, wege 1500 kcal"
+func (l Labels) PlanTitle(p Plan) string {
+	if p.Profile.IsZero() {
+		return l.Title(p.Date)
+	}
+	return l.Title(p.Date) + " – " + p.Profile.String()
+}
@@ -0,0 +1,54 @@
+package meal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabels(t *testing.T) {
+	date := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("polish dates", func(t *testing.T) {
+		assert.Equal(t, "czwartek", PolishLabels.Weekday(date))
+		assert.Equal(t, "01.10.2026", PolishLabels.FormatDate(date))
+		assert.Equal(t, "1 października 2026", PolishLabels.FormatLongDate(date))
+		assert.Equal(t, "Jadłospis 01.10.2026", PolishLabels.Title(date))
+	})
+
+	t.Run("zero date", func(t *testing.T) {
+		assert.Empty(t, PolishLabels.Weekday(time.Time{}))
+		assert.Empty(t, PolishLabels.FormatLongDate(time.Time{}))
+		assert.Equal(t, "Jadłospis", PolishLabels.Title(time.Time{}))
+	})
+
+	t.Run("markdown in another language", func(t *testing.T) {
+		plan := Plan{Meals: []Meal{{Name: "Lunch", Dishes: []Dish{{Name: "Soup", Ingredients: []string{"Water"}}}}}}
+		labels := PolishLabels
+		labels.Ingredients = "Ingredients"
+		assert.Equal(t, "# Lunch\n\n## Soup\n**Ingredients:**\n- Water\n\n", plan.FormatToMarkdownIn(labels))
+	})
+}
+
+func TestCanonicalName(t *testing.T) {
+	assert.Equal(t, "oliwa z oliwek", CanonicalName("  Oliwa  Z Oliwek* "))
+	assert.Equal(t, "ii śniadanie", CanonicalName("II Śniadanie:"))
+}
+
+func TestDishHasTags(t *testing.T) {
+	dish := Dish{Tags: []string{"Wege", "nowość"}}
+	assert.True(t, dish.HasTags([]string{"wege"}))
+	assert.True(t, dish.HasTags([]string{"nowość", "WEGE"}))
+	assert.True(t, dish.HasTags(nil))
+	assert.False(t, dish.HasTags([]string{"wege", "ostre"}))
+}
+
+func TestFormatToMarkdownAnnotations(t *testing.T) {
+	plan := Plan{Meals: []Meal{{Name: "Obiad", Dishes: []Dish{{Name: "Zupa", Ingredients: []string{"Woda"}, Annotations: []string{"Zmiany od 01.01.2026: +Sól"}}}}}}
+
+	assert.Equal(t, "# Obiad\n\n## Zupa\n> Zmiany od 01.01.2026: +Sól\n**Składniki:**\n- Woda\n\n", plan.FormatToMarkdown())
+
+	plan.Annotations = []string{"Przetworzenie (NOVA): 1.0"}
+	assert.Equal(t, "> Przetworzenie (NOVA): 1.0\n\n# Obiad\n\n## Zupa\n> Zmiany od 01.01.2026: +Sól\n**Składniki:**\n- Woda\n\n", plan.FormatToMarkdown())
+}
@@ -2,14 +2,35 @@ package meal
 
 import (
 	"encoding/json"
+	"slices"
 	"strings"
+	"time"
 )
 
-// Dish represents a single dish with its name and ingredients
+// Dish represents a single dish with its name and ingredients.
+// Tags are the provider's labels of the dish, e.g. "wege" or "nowość", and Instructions the
+// serving and heating instructions given with its name, e.g. "Lekko podgrzać".
+// Annotations are remarks added for display, such as changes since the dish was last served;
+// they are not part of the menu and are not written to JSON. Classification, when set, holds the
+// allergens and diets found in the ingredients before they were translated.
 type Dish struct {
-	Name            string   `json:"dishName"`
-	Ingredients     []string `json:"ingredients"`
-	IngredientsList string   `json:"ingredientsList,omitempty"`
+	Name            string          `json:"dishName"`
+	Ingredients     []string        `json:"ingredients"`
+	IngredientsList string          `json:"ingredientsList,omitempty"`
+	Tags            []string        `json:"tags,omitempty"`
+	Instructions    []string        `json:"instructions,omitempty"`
+	Annotations     []string        `json:"-"`
+	Classification  *Classification `json:"-"`
+}
+
+// HasTags reports whether the dish has all the tags, compared ignoring case
+func (d Dish) HasTags(tags []string) bool {
+	for _, tag := range tags {
+		if !slices.ContainsFunc(d.Tags, func(t string) bool { return CanonicalName(t) == CanonicalName(tag) }) {
+			return false
+		}
+	}
+	return true
 }
 
 // Meal represents a meal with its name and dishes
@@ -18,8 +39,32 @@ type Meal struct {
 	Dishes []Dish `json:"dishes"`
 }
 
-// Plan represents the structured data for all meals
-type Plan []Meal
+// Plan represents the structured data for all meals of a single day.
+// Profile tells whose plan it is, so that plans of several people for the same day can coexist.
+// Annotations are remarks about the whole day added for display, like those of a Dish.
+type Plan struct {
+	Date        time.Time
+	Profile     Profile
+	Meals       []Meal
+	Annotations []string
+}
+
+// Filter returns the plan with only the dishes to keep, leaving out meals without any
+func (p Plan) Filter(keep func(Dish) bool) Plan {
+	filtered := Plan{Date: p.Date, Profile: p.Profile, Annotations: p.Annotations}
+	for _, m := range p.Meals {
+		fm := Meal{Name: m.Name}
+		for _, d := range m.Dishes {
+			if keep(d) {
+				fm.Dishes = append(fm.Dishes, d)
+			}
+		}
+		if len(fm.Dishes) > 0 {
+			filtered.Meals = append(filtered.Meals, fm)
+		}
+	}
+	return filtered
+}
 
 // UnmarshalJSON custom unmarshaler for Dish to process IngredientsList
 func (d *Dish) UnmarshalJSON(data []byte) error {
@@ -48,18 +93,53 @@ func (d *Dish) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// annotationPrefix starts an annotation line in Markdown, rendered as a quote
+const annotationPrefix = "> "
+
+// frontMatterDelimiter encloses the front matter with the profile at the start of Markdown
+const frontMatterDelimiter = "---"
+
 // FormatToMarkdown converts a meal Plan to Markdown format
 func (p *Plan) FormatToMarkdown() string {
+	return p.FormatToMarkdownIn(PolishLabels)
+}
+
+// FormatToMarkdownIn converts a meal Plan to Markdown format with labels in the given language
+func (p *Plan) FormatToMarkdownIn(labels Labels) string {
 	var sb strings.Builder
 
+	if fields := p.Profile.Fields(); len(fields) > 0 {
+		sb.WriteString(frontMatterDelimiter + "\n")
+		for _, f := range fields {
+			sb.WriteString(f[0] + ": " + YAMLString(f[1]) + "\n")
+		}
+		sb.WriteString(frontMatterDelimiter + "\n\n")
+	}
+
+	if len(p.Annotations) > 0 {
+		for _, note := range p.Annotations {
+			sb.WriteString(annotationPrefix + note + "\n")
+		}
+		sb.WriteString("\n")
+	}
+
 	// Iterate through meals in the original order
-	for _, meal := range *p {
+	for _, meal := range p.Meals {
 		sb.WriteString("# " + meal.Name + "\n\n")
 
 		for _, dish := range meal.Dishes {
 			sb.WriteString("## " + dish.Name + "\n")
+			if len(dish.Tags) > 0 {
+				sb.WriteString("*" + labels.Tags + ":* " + strings.Join(dish.Tags, ", ") + "\n")
+			}
+			for _, instruction := range dish.Instructions {
+				sb.WriteString(annotationPrefix + "**" + labels.Instructions + ":** " + instruction + "\n")
+			}
+			for _, note := range dish.Annotations {
+				sb.WriteString(annotationPrefix + note + "\n")
+			}
 			if len(dish.Ingredients) > 0 {
-				sb.WriteString("**Składniki:**\n")
+				sb.WriteString("**" + labels.Ingredients + ":**\n")
 				for _, ing := range dish.Ingredients {
 					sb.WriteString("- " + ing + "\n")
 				}
@@ -0,0 +1,113 @@
+package meal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrUnknownSchemaVersion is returned for documents newer than this version of the tool understands
+var ErrUnknownSchemaVersion = errors.New("unknown schema version")
+
+// migrations upgrade a JSON document from the version of the key to the next version
+var migrations = map[int]func(data []byte) ([]byte, error){
+	0: migrateV0,
+}
+
+// DetectSchemaVersion returns the schema version of JSON data.
+// Bare arrays of meals, written before the format was versioned, are version 0.
+func DetectSchemaVersion(data []byte) (int, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		return 0, nil
+	}
+
+	var header struct {
+		SchemaVersion *int `json:"schemaVersion"`
+	}
+	if err := json.Unmarshal(trimmed, &header); err != nil {
+		return 0, err
+	}
+	if header.SchemaVersion == nil {
+		return 0, errors.New("missing schemaVersion")
+	}
+	return *header.SchemaVersion, nil
+}
+
+// Migrate upgrades JSON data in any known shape to the current Document
+// and returns it together with the version it was upgraded from
+func Migrate(data []byte) (Document, int, error) {
+	version, err := DetectSchemaVersion(data)
+	if err != nil {
+		return Document{}, 0, err
+	}
+	if version < 0 || version > SchemaVersion {
+		return Document{}, version, fmt.Errorf("%w: %d", ErrUnknownSchemaVersion, version)
+	}
+
+	for v := version; v < SchemaVersion; v++ {
+		data, err = migrations[v](data)
+		if err != nil {
+			return Document{}, version, fmt.Errorf("migrating from schema version %d: %w", v, err)
+		}
+	}
+
+	var doc Document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return Document{}, version, err
+	}
+	return doc, version, nil
+}
+
+// ParseDocument reads JSON data in any known shape into a Plan
+func ParseDocument(data []byte) (Plan, error) {
+	doc, _, err := Migrate(data)
+	if err != nil {
+		return Plan{}, err
+	}
+	return doc.ToPlan()
+}
+
+// ToPlan converts the document back to a Plan, processing raw ingredient lists and
+// separating instructions from dish names
+func (d *Document) ToPlan() (Plan, error) {
+	plan := Plan{Profile: Profile{Person: d.Person, Variant: d.Variant, Calories: d.Calories}}
+	if d.Date != "" {
+		date, err := time.Parse(dateLayout, d.Date)
+		if err != nil {
+			return Plan{}, err
+		}
+		plan.Date = date
+	}
+
+	for _, docMeal := range d.Meals {
+		m := Meal{Name: docMeal.Name}
+		for _, docDish := range docMeal.Dishes {
+			dish := Dish{Name: docDish.Name, Tags: docDish.Tags, Instructions: docDish.Instructions}
+			dish.ExtractInstructions()
+			for _, ing := range docDish.Ingredients {
+				dish.Ingredients = append(dish.Ingredients, ing.Text)
+			}
+			if len(dish.Ingredients) == 0 && docDish.IngredientsList != "" {
+				dish.Ingredients = ProcessIngredients(docDish.IngredientsList)
+			}
+			m.Dishes = append(m.Dishes, dish)
+		}
+		plan.Meals = append(plan.Meals, m)
+	}
+
+	return plan, nil
+}
+
+// migrateV0 wraps a bare array of meals with unprocessed ingredients into a version 1 document.
+// Derived fields are left out, as they are not read back.
+func migrateV0(data []byte) ([]byte, error) {
+	var meals []Meal
+	if err := json.Unmarshal(data, &meals); err != nil {
+		return nil, err
+	}
+	plan := Plan{Meals: meals}
+	return json.Marshal(plan.document())
+}
@@ -0,0 +1,102 @@
+package meal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectSchemaVersion(t *testing.T) {
+	t.Run("bare array", func(t *testing.T) {
+		version, err := DetectSchemaVersion([]byte(`  [{"mealName": "Obiad"}]`))
+		assert.NoError(t, err)
+		assert.Equal(t, 0, version)
+	})
+
+	t.Run("versioned document", func(t *testing.T) {
+		version, err := DetectSchemaVersion([]byte(`{"schemaVersion": 1, "meals": []}`))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, version)
+	})
+
+	t.Run("object without version", func(t *testing.T) {
+		_, err := DetectSchemaVersion([]byte(`{"meals": []}`))
+		assert.EqualError(t, err, "missing schemaVersion")
+	})
+}
+
+func TestParseDocument(t *testing.T) {
+	expected := Plan{Meals: []Meal{
+		{Name: "Obiad", Dishes: []Dish{
+			{Name: "Kurczak w sosie", Ingredients: []string{"Pierś z kurczaka (bez skóry)", "Śmietana 30%"}},
+		}},
+	}}
+
+	t.Run("version 0 with ingredientsList", func(t *testing.T) {
+		input := `[{"mealName": "Obiad", "dishes": [{"dishName": "Kurczak w sosie", "ingredientsList": "pierś z kurczaka, bez skóry, śmietana 30%"}]}]`
+
+		result, err := ParseDocument([]byte(input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("version 0 with ingredients", func(t *testing.T) {
+		input := `[{"mealName": "Obiad", "dishes": [{"dishName": "Kurczak w sosie", "ingredients": ["PIERŚ Z KURCZAKA, bez skóry", "śmietana 30%"]}]}]`
+
+		result, err := ParseDocument([]byte(input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("version 1 exported from the browser", func(t *testing.T) {
+		input := `{"schemaVersion": 1, "date": "2026-01-02", "meals": [{"mealName": "Obiad", "dishes": [{"dishName": "Kurczak w sosie", "ingredientsList": "pierś z kurczaka, bez skóry, śmietana 30%"}]}]}`
+
+		result, err := ParseDocument([]byte(input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), result.Date)
+		assert.Equal(t, expected.Meals, result.Meals)
+	})
+
+	t.Run("version 1 canonical round trip", func(t *testing.T) {
+		plan := expected
+		plan.Date = time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+		data, err := plan.FormatToJSON()
+		assert.NoError(t, err)
+
+		result, err := ParseDocument([]byte(data))
+
+		assert.NoError(t, err)
+		assert.Equal(t, plan, result)
+	})
+
+	t.Run("tags round trip", func(t *testing.T) {
+		plan := Plan{Meals: []Meal{{Name: "Obiad", Dishes: []Dish{{Name: "Dal", Ingredients: []string{"Soczewica"}, Tags: []string{"wege"}}}}}}
+		data, err := plan.FormatToJSON()
+		assert.NoError(t, err)
+
+		result, err := ParseDocument([]byte(data))
+
+		assert.NoError(t, err)
+		assert.Equal(t, plan, result)
+	})
+
+	t.Run("profile round trip", func(t *testing.T) {
+		plan := Plan{Profile: Profile{Person: "Anna", Variant: "wege", Calories: 1500}, Meals: []Meal{{Name: "Obiad", Dishes: []Dish{{Name: "Dal", Ingredients: []string{"Soczewica"}}}}}}
+		data, err := plan.FormatToJSON()
+		assert.NoError(t, err)
+
+		result, err := ParseDocument([]byte(data))
+
+		assert.NoError(t, err)
+		assert.Equal(t, plan, result)
+	})
+
+	t.Run("newer version", func(t *testing.T) {
+		_, err := ParseDocument([]byte(`{"schemaVersion": 99, "meals": []}`))
+		assert.ErrorIs(t, err, ErrUnknownSchemaVersion)
+	})
+}
@@ -0,0 +1,209 @@
+package meal
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// PlanDiff lists the structural differences between two versions of a plan, e.g. the same day exported
+// before and after a change of the diet variant. Meals and dishes are matched by their canonical names.
+// The dates are set only when they differ, empty for a plan without a date, and so are the profiles,
+// e.g. "wege 1500 kcal", empty for a plan without one.
+type PlanDiff struct {
+	FromDate     string     `json:"fromDate,omitempty"`
+	ToDate       string     `json:"toDate,omitempty"`
+	FromProfile  string     `json:"fromProfile,omitempty"`
+	ToProfile    string     `json:"toProfile,omitempty"`
+	AddedMeals   []Meal     `json:"addedMeals,omitempty"`
+	RemovedMeals []Meal     `json:"removedMeals,omitempty"`
+	Meals        []MealDiff `json:"meals,omitempty"`
+}
+
+// MealDiff lists the differences between the dishes of a meal present in both plans
+type MealDiff struct {
+	Name          string       `json:"mealName"`
+	AddedDishes   []string     `json:"addedDishes,omitempty"`
+	RemovedDishes []string     `json:"removedDishes,omitempty"`
+	Dishes        []DishChange `json:"changedDishes,omitempty"`
+}
+
+// DishChange is a dish present in both plans whose ingredients, tags or instructions changed
+type DishChange struct {
+	Name string `json:"dishName"`
+	DishDiff
+}
+
+// DiffPlans compares two plans. Meals and dishes keep the order of the second plan, followed by the removed ones.
+func DiffPlans(from, to Plan) PlanDiff {
+	var diff PlanDiff
+	if !from.Date.Equal(to.Date) {
+		diff.FromDate, diff.ToDate = formatDiffDate(from.Date), formatDiffDate(to.Date)
+	}
+	if from.Profile != to.Profile {
+		diff.FromProfile, diff.ToProfile = from.Profile.String(), to.Profile.String()
+	}
+
+	fromMeals := map[string]Meal{}
+	for _, m := range from.Meals {
+		fromMeals[CanonicalName(m.Name)] = m
+	}
+	toMeals := map[string]bool{}
+	for _, m := range to.Meals {
+		key := CanonicalName(m.Name)
+		toMeals[key] = true
+		previous, ok := fromMeals[key]
+		if !ok {
+			diff.AddedMeals = append(diff.AddedMeals, m)
+			continue
+		}
+		if md := diffMeals(previous, m); !md.IsEmpty() {
+			diff.Meals = append(diff.Meals, md)
+		}
+	}
+	for _, m := range from.Meals {
+		if !toMeals[CanonicalName(m.Name)] {
+			diff.RemovedMeals = append(diff.RemovedMeals, m)
+		}
+	}
+	return diff
+}
+
+// diffMeals compares the dishes of two versions of a meal
+func diffMeals(from, to Meal) MealDiff {
+	diff := MealDiff{Name: to.Name}
+	fromDishes := map[string]Dish{}
+	for _, d := range from.Dishes {
+		fromDishes[CanonicalName(d.Name)] = d
+	}
+	toDishes := map[string]bool{}
+	for _, d := range to.Dishes {
+		key := CanonicalName(d.Name)
+		toDishes[key] = true
+		previous, ok := fromDishes[key]
+		if !ok {
+			diff.AddedDishes = append(diff.AddedDishes, d.Name)
+			continue
+		}
+		if dd := DiffDishes(previous, d); !dd.IsEmpty() {
+			diff.Dishes = append(diff.Dishes, DishChange{Name: d.Name, DishDiff: dd})
+		}
+	}
+	for _, d := range from.Dishes {
+		if !toDishes[CanonicalName(d.Name)] {
+			diff.RemovedDishes = append(diff.RemovedDishes, d.Name)
+		}
+	}
+	return diff
+}
+
+// IsEmpty reports whether the dishes of the meal did not change
+func (d MealDiff) IsEmpty() bool {
+	return len(d.AddedDishes) == 0 && len(d.RemovedDishes) == 0 && len(d.Dishes) == 0
+}
+
+// IsEmpty reports whether the plans have the same date, profile, meals and dishes, with the same
+// ingredients, tags and instructions
+func (d PlanDiff) IsEmpty() bool {
+	return d.FromDate == d.ToDate && d.FromProfile == d.ToProfile && len(d.AddedMeals) == 0 && len(d.RemovedMeals) == 0 && len(d.Meals) == 0
+}
+
+// FormatToText renders the differences as plain text, with "+" for added, "-" for removed
+// and "~" for changed meals and dishes
+func (d PlanDiff) FormatToText() string {
+	var sb strings.Builder
+	if d.FromDate != d.ToDate {
+		sb.WriteString("Date: " + orNone(d.FromDate) + " → " + orNone(d.ToDate) + "\n")
+	}
+	if d.FromProfile != d.ToProfile {
+		sb.WriteString("Profile: " + orNone(d.FromProfile) + " → " + orNone(d.ToProfile) + "\n")
+	}
+	for _, m := range d.AddedMeals {
+		sb.WriteString("+ " + m.Name + "\n")
+		for _, dish := range m.Dishes {
+			sb.WriteString("  + " + dish.Name + "\n")
+		}
+	}
+	for _, m := range d.RemovedMeals {
+		sb.WriteString("- " + m.Name + "\n")
+		for _, dish := range m.Dishes {
+			sb.WriteString("  - " + dish.Name + "\n")
+		}
+	}
+	for _, m := range d.Meals {
+		sb.WriteString("~ " + m.Name + "\n")
+		for _, name := range m.AddedDishes {
+			sb.WriteString("  + " + name + "\n")
+		}
+		for _, name := range m.RemovedDishes {
+			sb.WriteString("  - " + name + "\n")
+		}
+		for _, dish := range m.Dishes {
+			sb.WriteString("  ~ " + dish.Name + ": " + dish.DishDiff.String() + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// FormatToMarkdown renders the differences as Markdown with a section for every changed meal
+func (d PlanDiff) FormatToMarkdown() string {
+	var sb strings.Builder
+	if d.FromDate != d.ToDate {
+		sb.WriteString("**Date:** " + orNone(d.FromDate) + " → " + orNone(d.ToDate) + "\n\n")
+	}
+	if d.FromProfile != d.ToProfile {
+		sb.WriteString("**Profile:** " + orNone(d.FromProfile) + " → " + orNone(d.ToProfile) + "\n\n")
+	}
+	for _, m := range d.AddedMeals {
+		sb.WriteString("# " + m.Name + " (added)\n\n")
+		for _, dish := range m.Dishes {
+			sb.WriteString("- **" + dish.Name + "**\n")
+		}
+		sb.WriteString("\n")
+	}
+	for _, m := range d.RemovedMeals {
+		sb.WriteString("# " + m.Name + " (removed)\n\n")
+		for _, dish := range m.Dishes {
+			sb.WriteString("- ~~" + dish.Name + "~~\n")
+		}
+		sb.WriteString("\n")
+	}
+	for _, m := range d.Meals {
+		sb.WriteString("# " + m.Name + "\n\n")
+		for _, name := range m.AddedDishes {
+			sb.WriteString("- Added: **" + name + "**\n")
+		}
+		for _, name := range m.RemovedDishes {
+			sb.WriteString("- Removed: ~~" + name + "~~\n")
+		}
+		for _, dish := range m.Dishes {
+			sb.WriteString("- Changed: **" + dish.Name + "**: " + dish.DishDiff.String() + "\n")
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// FormatToJSON renders the differences as JSON
+func (d PlanDiff) FormatToJSON() (string, error) {
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// formatDiffDate formats the date of a compared plan, which is missing in Markdown day files
+func formatDiffDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.Format("2006-01-02")
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
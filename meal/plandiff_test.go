@@ -0,0 +1,110 @@
+package meal
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffPlans(t *testing.T) {
+	from := Plan{
+		Date: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		Meals: []Meal{
+			{Name: "Śniadanie", Dishes: []Dish{
+				{Name: "Owsianka", Ingredients: []string{"Płatki owsiane", "Mleko 3.2%"}},
+				{Name: "Kawa", Ingredients: []string{"Kawa"}},
+			}},
+			{Name: "Podwieczorek", Dishes: []Dish{{Name: "Jabłko"}}},
+			{Name: "Obiad", Dishes: []Dish{{Name: "Zupa pomidorowa", Ingredients: []string{"Pomidory"}}}},
+		},
+	}
+
+	t.Run("same plan", func(t *testing.T) {
+		diff := DiffPlans(from, from)
+
+		assert.True(t, diff.IsEmpty())
+		assert.Empty(t, diff.FormatToText())
+	})
+
+	t.Run("changed meals and dishes", func(t *testing.T) {
+		to := Plan{
+			Date: from.Date,
+			Meals: []Meal{
+				{Name: "śniadanie", Dishes: []Dish{
+					{Name: "Owsianka", Ingredients: []string{"Płatki owsiane", "Mleko 2%"}},
+					{Name: "Herbata"},
+				}},
+				{Name: "Obiad", Dishes: []Dish{{Name: "Zupa pomidorowa", Ingredients: []string{"Pomidory"}}}},
+				{Name: "Kolacja", Dishes: []Dish{{Name: "Kanapki"}}},
+			},
+		}
+
+		diff := DiffPlans(from, to)
+
+		require.False(t, diff.IsEmpty())
+		assert.Empty(t, diff.FromDate)
+		assert.Equal(t, "Kolacja", diff.AddedMeals[0].Name)
+		assert.Equal(t, "Podwieczorek", diff.RemovedMeals[0].Name)
+		require.Len(t, diff.Meals, 1)
+		assert.Equal(t, MealDiff{
+			Name:          "śniadanie",
+			AddedDishes:   []string{"Herbata"},
+			RemovedDishes: []string{"Kawa"},
+			Dishes: []DishChange{{Name: "Owsianka", DishDiff: DishDiff{
+				Percentages: []PercentageChange{{Name: "Mleko", From: 3.2, To: 2}},
+			}}},
+		}, diff.Meals[0])
+
+		assert.Equal(t, "+ Kolacja\n  + Kanapki\n- Podwieczorek\n  - Jabłko\n~ śniadanie\n  + Herbata\n  - Kawa\n  ~ Owsianka: Mleko 3.2% → 2%\n", diff.FormatToText())
+		assert.Contains(t, diff.FormatToMarkdown(), "# śniadanie\n\n- Added: **Herbata**\n- Removed: ~~Kawa~~\n- Changed: **Owsianka**: Mleko 3.2% → 2%\n")
+	})
+
+	t.Run("changed date", func(t *testing.T) {
+		to := Plan{Meals: from.Meals}
+
+		diff := DiffPlans(from, to)
+
+		assert.False(t, diff.IsEmpty())
+		assert.Equal(t, "Date: 2025-03-01 → none\n", diff.FormatToText())
+
+		data, err := diff.FormatToJSON()
+		require.NoError(t, err)
+		var decoded map[string]any
+		require.NoError(t, json.Unmarshal([]byte(data), &decoded))
+		assert.Equal(t, map[string]any{"fromDate": "2025-03-01"}, decoded)
+	})
+
+	t.Run("changed profile", func(t *testing.T) {
+		before := from
+		before.Profile = Profile{Person: "Anna", Variant: "wege", Calories: 1500}
+		after := before
+		after.Profile.Calories = 2000
+
+		diff := DiffPlans(before, after)
+
+		assert.False(t, diff.IsEmpty())
+		assert.Equal(t, "Profile: Anna, wege 1500 kcal → Anna, wege 2000 kcal\n", diff.FormatToText())
+		assert.Equal(t, "**Profile:** Anna, wege 1500 kcal → Anna, wege 2000 kcal\n\n", diff.FormatToMarkdown())
+		assert.Equal(t, "Profile: none → Anna, wege 1500 kcal\n", DiffPlans(from, before).FormatToText())
+
+		data, err := diff.FormatToJSON()
+		require.NoError(t, err)
+		var decoded map[string]any
+		require.NoError(t, json.Unmarshal([]byte(data), &decoded))
+		assert.Equal(t, map[string]any{"fromProfile": "Anna, wege 1500 kcal", "toProfile": "Anna, wege 2000 kcal"}, decoded)
+	})
+
+	t.Run("changed tags and instructions", func(t *testing.T) {
+		to := Plan{Date: from.Date, Meals: []Meal{
+			{Name: "Obiad", Dishes: []Dish{{Name: "Zupa pomidorowa", Ingredients: []string{"Pomidory"}, Tags: []string{"wege"}, Instructions: []string{"Podgrzać"}}}},
+		}}
+		before := Plan{Date: from.Date, Meals: []Meal{from.Meals[2]}}
+
+		diff := DiffPlans(before, to)
+
+		assert.Equal(t, "~ Obiad\n  ~ Zupa pomidorowa: +#wege; +\"Podgrzać\"\n", diff.FormatToText())
+	})
+}
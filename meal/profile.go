@@ -0,0 +1,112 @@
+package meal
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Profile tells whose plan it is: the person eating it and the provider's diet variant with its
+// calorie target, e.g. "Anna", "wege" and 1500. Any of them may be unknown.
+type Profile struct {
+	Person   string
+	Variant  string
+	Calories int
+}
+
+// caloriesRegexp matches a calorie level in a variant name, e.g. "1500 kcal"
+var caloriesRegexp = regexp.MustCompile(`(?i)\s*\b(\d{3,5})\s*kcal\b\s*`)
+
+// variantSeparators separate the calorie level from the rest of a variant name, as in "Sport – 2500 kcal"
+const variantSeparators = " ,-–"
+
+// ParseVariant separates the calorie level from the name of a diet variant,
+// e.g. "Wege 1500 kcal" is split into "Wege" and 1500
+func ParseVariant(s string) (string, int) {
+	m := caloriesRegexp.FindStringSubmatchIndex(s)
+	if m == nil {
+		return strings.TrimSpace(s), 0
+	}
+	calories, _ := strconv.Atoi(s[m[2]:m[3]])
+	before := strings.TrimRight(s[:m[0]], variantSeparators)
+	after := strings.TrimLeft(s[m[1]:], variantSeparators)
+	return strings.TrimSpace(before + " " + after), calories
+}
+
+// IsZero reports whether nothing is known about whose plan it is
+func (p Profile) IsZero() bool {
+	return p == Profile{}
+}
+
+// Merge returns the profile with the fields set in other replacing its own
+func (p Profile) Merge(other Profile) Profile {
+	if other.Person != "" {
+		p.Person = other.Person
+	}
+	if other.Variant != "" {
+		p.Variant = other.Variant
+	}
+	if other.Calories != 0 {
+		p.Calories = other.Calories
+	}
+	return p
+}
+
+// String returns the profile for headers, e.g. "Anna, wege 1500 kcal"
+func (p Profile) String() string {
+	variant := p.Variant
+	if p.Calories != 0 {
+		variant = strings.TrimSpace(variant + " " + strconv.Itoa(p.Calories) + " kcal")
+	}
+	var parts []string
+	for _, part := range []string{p.Person, variant} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Slug returns the profile for file names, e.g. "anna-wege-1500kcal", or an empty string for a zero profile
+func (p Profile) Slug() string {
+	parts := append(words(p.Person), words(p.Variant)...)
+	if p.Calories != 0 {
+		parts = append(parts, strconv.Itoa(p.Calories)+"kcal")
+	}
+	return strings.Join(parts, "-")
+}
+
+// Fields returns the keys and values of the fields set, as written in Markdown front matter
+func (p Profile) Fields() [][2]string {
+	var fields [][2]string
+	if p.Person != "" {
+		fields = append(fields, [2]string{"person", p.Person})
+	}
+	if p.Variant != "" {
+		fields = append(fields, [2]string{"variant", p.Variant})
+	}
+	if p.Calories != 0 {
+		fields = append(fields, [2]string{"calories", strconv.Itoa(p.Calories)})
+	}
+	return fields
+}
+
+// SetField sets the field named by a key of Fields
+func (p *Profile) SetField(key, value string) error {
+	switch key {
+	case "person":
+		p.Person = value
+	case "variant":
+		p.Variant = value
+	case "calories":
+		calories, err := strconv.Atoi(value)
+		if err != nil || calories < 0 {
+			return fmt.Errorf("invalid calories %q", value)
+		}
+		p.Calories = calories
+	default:
+		return fmt.Errorf("unknown profile field %q", key)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package meal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVariant(t *testing.T) {
+	tests := []struct {
+		input    string
+		name     string
+		calories int
+	}{
+		{input: "Wege 1500 kcal", name: "Wege", calories: 1500},
+		{input: "2000kcal", name: "", calories: 2000},
+		{input: "Sport – 2500 kcal – bez laktozy", name: "Sport bez laktozy", calories: 2500},
+		{input: "wege", name: "wege"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			name, calories := ParseVariant(tt.input)
+
+			assert.Equal(t, tt.name, name)
+			assert.Equal(t, tt.calories, calories)
+		})
+	}
+}
+
+func TestProfile(t *testing.T) {
+	profile := Profile{Person: "Anna Maria", Variant: "Wege", Calories: 1500}
+
+	t.Run("string", func(t *testing.T) {
+		assert.Equal(t, "Anna Maria, Wege 1500 kcal", profile.String())
+		assert.Equal(t, "1500 kcal", Profile{Calories: 1500}.String())
+		assert.Equal(t, "", Profile{}.String())
+	})
+
+	t.Run("slug", func(t *testing.T) {
+		assert.Equal(t, "anna-maria-wege-1500kcal", profile.Slug())
+		assert.Equal(t, "", Profile{}.Slug())
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		assert.Equal(t, Profile{Person: "Piotr", Variant: "Wege", Calories: 1500}, profile.Merge(Profile{Person: "Piotr"}))
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		var parsed Profile
+		for _, f := range profile.Fields() {
+			assert.NoError(t, parsed.SetField(f[0], f[1]))
+		}
+		assert.Equal(t, profile, parsed)
+	})
+
+	t.Run("invalid fields", func(t *testing.T) {
+		var p Profile
+		assert.EqualError(t, p.SetField("calories", "dużo"), `invalid calories "dużo"`)
+		assert.EqualError(t, p.SetField("age", "30"), `unknown profile field "age"`)
+	})
+
+	t.Run("plan title", func(t *testing.T) {
+		plan := Plan{Date: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Profile: profile}
+		assert.Equal(t, "Jadłospis 01.01.2026 – Anna Maria, Wege 1500 kcal", PolishLabels.PlanTitle(plan))
+		assert.Equal(t, "Jadłospis 01.01.2026", PolishLabels.PlanTitle(Plan{Date: plan.Date}))
+	})
+}
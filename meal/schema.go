@@ -0,0 +1,113 @@
+package meal
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// schemaID is the published location of the JSON Schema of the canonical document
+const schemaID = "https://github.com/toszr/dietician/schema/plan.schema.json"
+
+// JSONSchema generates the JSON Schema of the canonical document from the Go types.
+// Field descriptions come from the jsonschema struct tags.
+func JSONSchema() (string, error) {
+	defs := map[string]any{}
+	root := schemaFor(reflect.TypeOf(Document{}), defs)
+
+	schema := map[string]any{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$id":     schemaID,
+		"title":   "Dietician meal plan",
+		"$ref":    root["$ref"],
+		"$defs":   defs,
+	}
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// schemaFor returns the schema of t, registering named struct types in defs
+func schemaFor(t reflect.Type, defs map[string]any) map[string]any {
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": schemaFor(t.Elem(), defs)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaFor(t.Elem(), defs)}
+	case reflect.Pointer:
+		return schemaFor(t.Elem(), defs)
+	case reflect.Struct:
+		ref := map[string]any{"$ref": "#/$defs/" + t.Name()}
+		if _, ok := defs[t.Name()]; ok {
+			return ref
+		}
+		// Register the name before descending, so recursive types refer to themselves
+		defs[t.Name()] = nil
+
+		properties := map[string]any{}
+		required := []string{}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, omitempty, ok := jsonFieldName(field)
+			if !ok {
+				continue
+			}
+			prop := schemaFor(field.Type, defs)
+			if desc := field.Tag.Get("jsonschema"); desc != "" {
+				prop = withDescription(prop, desc)
+			}
+			properties[name] = prop
+			if !omitempty {
+				required = append(required, name)
+			}
+		}
+		defs[t.Name()] = map[string]any{
+			"type":                 "object",
+			"properties":           properties,
+			"required":             required,
+			"additionalProperties": false,
+		}
+		return ref
+	default:
+		panic(fmt.Sprintf("meal: no JSON Schema for kind %s", t.Kind()))
+	}
+}
+
+// jsonFieldName returns the JSON name of an exported field and whether it is omitted when empty
+func jsonFieldName(field reflect.StructField) (string, bool, bool) {
+	if !field.IsExported() {
+		return "", false, false
+	}
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, false
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+	return name, strings.Contains(opts, "omitempty"), true
+}
+
+// withDescription returns a copy of a property schema with a description added
+func withDescription(prop map[string]any, desc string) map[string]any {
+	out := make(map[string]any, len(prop)+1)
+	for k, v := range prop {
+		out[k] = v
+	}
+	out["description"] = desc
+	return out
+}
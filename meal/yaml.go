@@ -0,0 +1,33 @@
+package meal
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// plainScalarRegexp matches strings that are safe as unquoted YAML scalars
+var plainScalarRegexp = regexp.MustCompile(`^[\p{L}\p{N}][\p{L}\p{N} .,()%'-]*$`)
+
+// YAMLString quotes a string for YAML unless it can be written as a plain scalar
+func YAMLString(s string) string {
+	if plainScalarRegexp.MatchString(s) && !strings.HasSuffix(s, " ") {
+		return s
+	}
+	return strconv.Quote(s)
+}
+
+// ParseYAMLString reads a scalar written by YAMLString, or a plain or single-quoted one
+func ParseYAMLString(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case strings.HasPrefix(s, `"`):
+		return strconv.Unquote(s)
+	case strings.HasPrefix(s, "'"):
+		if len(s) < 2 || !strings.HasSuffix(s, "'") {
+			return "", strconv.ErrSyntax
+		}
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), nil
+	}
+	return s, nil
+}
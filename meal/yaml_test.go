@@ -0,0 +1,35 @@
+package meal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestYAMLString(t *testing.T) {
+	assert.Equal(t, "II śniadanie", YAMLString("II śniadanie"))
+	assert.Equal(t, `"Obiad: zupa"`, YAMLString("Obiad: zupa"))
+	assert.Equal(t, `"- lista"`, YAMLString("- lista"))
+}
+
+func TestParseYAMLString(t *testing.T) {
+	for _, s := range []string{"Anna", "Anna #2: mama", `"cudzysłów"`, "- lista", "koniec "} {
+		t.Run(s, func(t *testing.T) {
+			parsed, err := ParseYAMLString(YAMLString(s))
+			require.NoError(t, err)
+			assert.Equal(t, s, parsed)
+		})
+	}
+
+	t.Run("single-quoted", func(t *testing.T) {
+		parsed, err := ParseYAMLString("'Anna''s'")
+		require.NoError(t, err)
+		assert.Equal(t, "Anna's", parsed)
+	})
+
+	t.Run("unterminated", func(t *testing.T) {
+		_, err := ParseYAMLString(`"Anna`)
+		assert.Error(t, err)
+	})
+}
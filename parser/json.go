@@ -1,18 +1,20 @@
 package parser
 
 import (
-	"encoding/json"
-
 	"github.com/toszr/dietician/meal"
 )
 
 // ParseJSONToMarkdown parses JSON data and returns Markdown output
 func ParseJSONToMarkdown(data []byte) (string, error) {
-	var mealPlan meal.Plan
-	err := json.Unmarshal(data, &mealPlan)
+	mealPlan, err := ParseJSON(data)
 	if err != nil {
 		return "", err
 	}
 
 	return mealPlan.FormatToMarkdown(), nil
 }
+
+// ParseJSON converts JSON data in any known schema version to the structured meal.Plan format
+func ParseJSON(data []byte) (meal.Plan, error) {
+	return meal.ParseDocument(data)
+}
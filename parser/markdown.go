@@ -0,0 +1,134 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/toszr/dietician/meal"
+)
+
+const (
+	mealHeadingPrefix = "# "
+	dishHeadingPrefix = "## "
+	ingredientPrefix  = "- "
+	annotationPrefix  = "> "
+	// frontMatterDelimiter encloses the profile of the plan at the start of the file
+	frontMatterDelimiter = "---"
+)
+
+// ingredientsLabelRegexp matches the ingredients label in any language, e.g. **Składniki:**
+var ingredientsLabelRegexp = regexp.MustCompile(`^\*\*[^*]+:\*\*$`)
+
+// instructionRegexp matches an instruction of a dish with its label in any language, quoted as a callout,
+// e.g. > **Wskazówki:** Lekko podgrzać
+var instructionRegexp = regexp.MustCompile(`^> \*\*[^*]+:\*\* (.+)$`)
+
+// tagsRegexp matches the tags of a dish with their label in any language, e.g. *Tagi:* wege, ostre
+var tagsRegexp = regexp.MustCompile(`^\*[^*]+:\* (.+)$`)
+
+// ParseMarkdownToMarkdown parses Markdown data and returns it re-formatted as Markdown output
+func ParseMarkdownToMarkdown(data []byte) (string, error) {
+	mealPlan, err := ParseMarkdown(data)
+	if err != nil {
+		return "", err
+	}
+
+	return mealPlan.FormatToMarkdown(), nil
+}
+
+// ParseMarkdown reads Markdown produced by meal.Plan.FormatToMarkdown back into a meal.Plan.
+// Ingredients are taken verbatim, as they have already been processed when the Markdown was generated.
+func ParseMarkdown(data []byte) (meal.Plan, error) {
+	mealPlan := meal.Plan{}
+	var currentMeal *meal.Meal
+	var currentDish *meal.Dish
+	inFrontMatter := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+
+		if lineNo == 1 && line == frontMatterDelimiter {
+			inFrontMatter = true
+			continue
+		}
+		if inFrontMatter {
+			if line == frontMatterDelimiter {
+				inFrontMatter = false
+				continue
+			}
+			key, value, ok := strings.Cut(line, ":")
+			if !ok {
+				return meal.Plan{}, fmt.Errorf("line %d: expected \"key: value\" in front matter", lineNo)
+			}
+			value, err := meal.ParseYAMLString(value)
+			if err != nil {
+				return meal.Plan{}, fmt.Errorf("line %d: invalid value in front matter: %w", lineNo, err)
+			}
+			if err := mealPlan.Profile.SetField(strings.TrimSpace(key), value); err != nil {
+				return meal.Plan{}, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			continue
+		}
+
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, mealHeadingPrefix):
+			mealPlan.Meals = append(mealPlan.Meals, meal.Meal{Name: strings.TrimSpace(line[len(mealHeadingPrefix):])})
+			currentMeal = &mealPlan.Meals[len(mealPlan.Meals)-1]
+			currentDish = nil
+		case strings.HasPrefix(line, dishHeadingPrefix):
+			if currentMeal == nil {
+				return meal.Plan{}, fmt.Errorf("line %d: dish heading outside of a meal", lineNo)
+			}
+			currentMeal.Dishes = append(currentMeal.Dishes, meal.Dish{Name: strings.TrimSpace(line[len(dishHeadingPrefix):])})
+			currentDish = &currentMeal.Dishes[len(currentMeal.Dishes)-1]
+			// Markdown written before instructions were separated has them in the heading
+			currentDish.ExtractInstructions()
+		case instructionRegexp.MatchString(line):
+			if currentDish == nil {
+				return meal.Plan{}, fmt.Errorf("line %d: instruction outside of a dish", lineNo)
+			}
+			currentDish.Instructions = append(currentDish.Instructions, instructionRegexp.FindStringSubmatch(line)[1])
+		case strings.HasPrefix(line, annotationPrefix):
+			// Annotations are added for display and are not part of the menu; those of the day precede the meals
+			if currentMeal != nil && currentDish == nil {
+				return meal.Plan{}, fmt.Errorf("line %d: annotation outside of a dish", lineNo)
+			}
+		case tagsRegexp.MatchString(line):
+			if currentDish == nil {
+				return meal.Plan{}, fmt.Errorf("line %d: tags outside of a dish", lineNo)
+			}
+			for _, tag := range strings.Split(tagsRegexp.FindStringSubmatch(line)[1], ",") {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					currentDish.Tags = append(currentDish.Tags, tag)
+				}
+			}
+		case ingredientsLabelRegexp.MatchString(line):
+			if currentDish == nil {
+				return meal.Plan{}, fmt.Errorf("line %d: ingredients label outside of a dish", lineNo)
+			}
+		case strings.HasPrefix(line, ingredientPrefix):
+			if currentDish == nil {
+				return meal.Plan{}, fmt.Errorf("line %d: ingredient outside of a dish", lineNo)
+			}
+			currentDish.Ingredients = append(currentDish.Ingredients, strings.TrimSpace(line[len(ingredientPrefix):]))
+		default:
+			return meal.Plan{}, fmt.Errorf("line %d: unexpected content %q", lineNo, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return meal.Plan{}, err
+	}
+	if inFrontMatter {
+		return meal.Plan{}, fmt.Errorf("line %d: unterminated front matter", lineNo)
+	}
+
+	return mealPlan, nil
+}
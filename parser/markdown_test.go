@@ -0,0 +1,189 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/toszr/dietician/meal"
+)
+
+func TestParseMarkdown(t *testing.T) {
+	t.Run("empty markdown", func(t *testing.T) {
+		result, err := ParseMarkdown([]byte(""))
+
+		assert.NoError(t, err)
+		assert.Empty(t, result.Meals)
+	})
+
+	t.Run("meals, dishes and ingredients", func(t *testing.T) {
+		input := "# Śniadanie\n\n## Jajecznica\n**Składniki:**\n- Jajka 2 szt.\n- Masło 10g\n\n## Herbata\n\n# Obiad\n\n"
+		expected := meal.Plan{Meals: []meal.Meal{
+			{Name: "Śniadanie", Dishes: []meal.Dish{
+				{Name: "Jajecznica", Ingredients: []string{"Jajka 2 szt.", "Masło 10g"}},
+				{Name: "Herbata"},
+			}},
+			{Name: "Obiad"},
+		}}
+
+		result, err := ParseMarkdown([]byte(input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("ingredients are not processed again", func(t *testing.T) {
+		input := "# Obiad\n\n## Kotlet\n**Składniki:**\n- Mięso wieprzowe (schab 80.5%)\n- Mleko bezlaktozowe 1\n- 5%uht\n\n"
+
+		result, err := ParseMarkdown([]byte(input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Mięso wieprzowe (schab 80.5%)", "Mleko bezlaktozowe 1", "5%uht"}, result.Meals[0].Dishes[0].Ingredients)
+	})
+
+	t.Run("ingredients label in another language", func(t *testing.T) {
+		input := "# Lunch\n\n## Soup\n**Ingredients:**\n- Water\n\n"
+
+		result, err := ParseMarkdown([]byte(input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Water"}, result.Meals[0].Dishes[0].Ingredients)
+	})
+
+	t.Run("annotations are skipped", func(t *testing.T) {
+		input := "> Przetworzenie (NOVA): 2.0\n\n# Obiad\n\n## Zupa\n> Zmiany od 01.01.2026: +Sól\n**Składniki:**\n- Woda\n\n"
+
+		result, err := ParseMarkdown([]byte(input))
+
+		assert.NoError(t, err)
+		assert.Empty(t, result.Annotations)
+		assert.Equal(t, []meal.Dish{{Name: "Zupa", Ingredients: []string{"Woda"}}}, result.Meals[0].Dishes)
+	})
+
+	t.Run("tags", func(t *testing.T) {
+		input := "# Obiad\n\n## Zupa\n*Tagi:* wege, nowość\n**Składniki:**\n- Woda\n\n"
+
+		result, err := ParseMarkdown([]byte(input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, []meal.Dish{{Name: "Zupa", Ingredients: []string{"Woda"}, Tags: []string{"wege", "nowość"}}}, result.Meals[0].Dishes)
+		assert.Equal(t, input, result.FormatToMarkdown())
+	})
+
+	t.Run("tags outside of a dish", func(t *testing.T) {
+		_, err := ParseMarkdown([]byte("# Obiad\n*Tagi:* wege\n"))
+
+		assert.EqualError(t, err, "line 2: tags outside of a dish")
+	})
+
+	t.Run("profile in front matter", func(t *testing.T) {
+		input := "---\nperson: Anna\nvariant: wege\ncalories: 1500\n---\n\n# Obiad\n\n## Zupa\n\n"
+
+		result, err := ParseMarkdown([]byte(input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, meal.Profile{Person: "Anna", Variant: "wege", Calories: 1500}, result.Profile)
+		assert.Equal(t, input, result.FormatToMarkdown())
+	})
+
+	t.Run("quoted profile in front matter", func(t *testing.T) {
+		input := "---\nperson: \"Anna #2: mama\"\nvariant: wege\n---\n\n# Obiad\n\n## Zupa\n\n"
+
+		result, err := ParseMarkdown([]byte(input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, meal.Profile{Person: "Anna #2: mama", Variant: "wege"}, result.Profile)
+		assert.Equal(t, input, result.FormatToMarkdown())
+	})
+
+	t.Run("invalid front matter", func(t *testing.T) {
+		_, err := ParseMarkdown([]byte("---\ncalories: dużo\n---\n"))
+		assert.EqualError(t, err, `line 2: invalid calories "dużo"`)
+
+		_, err = ParseMarkdown([]byte("---\nperson\n---\n"))
+		assert.EqualError(t, err, `line 2: expected "key: value" in front matter`)
+
+		_, err = ParseMarkdown([]byte("---\nperson: \"Anna\n---\n"))
+		assert.EqualError(t, err, "line 2: invalid value in front matter: invalid syntax")
+
+		_, err = ParseMarkdown([]byte("---\nperson: Anna\n"))
+		assert.EqualError(t, err, "line 2: unterminated front matter")
+	})
+
+	t.Run("instructions", func(t *testing.T) {
+		input := "# Obiad\n\n## Zupa\n> **Wskazówki:** Lekko podgrzać\n**Składniki:**\n- Woda\n\n"
+
+		result, err := ParseMarkdown([]byte(input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, []meal.Dish{{Name: "Zupa", Ingredients: []string{"Woda"}, Instructions: []string{"Lekko podgrzać"}}}, result.Meals[0].Dishes)
+		assert.Equal(t, input, result.FormatToMarkdown())
+	})
+
+	t.Run("instructions in a dish heading", func(t *testing.T) {
+		result, err := ParseMarkdown([]byte("# Obiad\n\n## Zupa (lekko podgrzać)\n"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, []meal.Dish{{Name: "Zupa", Instructions: []string{"Lekko podgrzać"}}}, result.Meals[0].Dishes)
+	})
+
+	t.Run("instruction outside of a dish", func(t *testing.T) {
+		_, err := ParseMarkdown([]byte("# Obiad\n> **Wskazówki:** Lekko podgrzać\n"))
+
+		assert.EqualError(t, err, "line 2: instruction outside of a dish")
+	})
+
+	t.Run("annotation outside of a dish", func(t *testing.T) {
+		_, err := ParseMarkdown([]byte("# Obiad\n> Uwaga\n"))
+
+		assert.EqualError(t, err, "line 2: annotation outside of a dish")
+	})
+
+	t.Run("windows line endings", func(t *testing.T) {
+		input := "# Obiad\r\n\r\n## Zupa\r\n**Składniki:**\r\n- Woda\r\n\r\n"
+
+		result, err := ParseMarkdown([]byte(input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "# Obiad\n\n## Zupa\n**Składniki:**\n- Woda\n\n", result.FormatToMarkdown())
+	})
+
+	t.Run("dish outside of a meal", func(t *testing.T) {
+		_, err := ParseMarkdown([]byte("## Zupa\n"))
+
+		assert.EqualError(t, err, "line 1: dish heading outside of a meal")
+	})
+
+	t.Run("ingredient outside of a dish", func(t *testing.T) {
+		_, err := ParseMarkdown([]byte("# Obiad\n\n- Woda\n"))
+
+		assert.EqualError(t, err, "line 3: ingredient outside of a dish")
+	})
+
+	t.Run("unexpected content", func(t *testing.T) {
+		_, err := ParseMarkdown([]byte("# Obiad\n\nSmacznego!\n"))
+
+		assert.EqualError(t, err, `line 3: unexpected content "Smacznego!"`)
+	})
+}
+
+func TestParseMarkdownRoundTripSamples(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "samples", "*.md"))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			data, err := os.ReadFile(file)
+			require.NoError(t, err)
+
+			result, err := ParseMarkdownToMarkdown(data)
+
+			assert.NoError(t, err)
+			assert.Equal(t, string(data), result)
+		})
+	}
+}
@@ -1,19 +1,104 @@
 package parser
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
+
+	"github.com/toszr/dietician/meal"
 )
 
+// dayFileLayout is the date layout at the start of day file names
+const dayFileLayout = "020106"
+
+// ErrUnsupportedFormat is returned by ParseFile for files it cannot parse
+var ErrUnsupportedFormat = errors.New("unsupported file type")
+
 // GetOutputPath returns the output path: if outputPath is empty, replaces inputPath's extension with .md
 func GetOutputPath(inputPath, outputPath string) string {
+	return GetOutputPathWithExt(inputPath, outputPath, ".md")
+}
+
+// GetOutputPathWithExt returns the output path: if outputPath is empty, replaces inputPath's extension with ext
+func GetOutputPathWithExt(inputPath, outputPath, ext string) string {
 	if outputPath != "" {
 		return outputPath
 	}
 	outPath := inputPath
 	if dot := strings.LastIndex(outPath, "."); dot != -1 {
-		outPath = outPath[:dot] + ".md"
+		outPath = outPath[:dot] + ext
 	} else {
-		outPath = outPath + ".md"
+		outPath = outPath + ext
 	}
 	return outPath
 }
+
+// GetPlanOutputPath returns the output path: if outputPath is empty, names the file after the plan with FileName
+// in the directory of inputPath, or replaces inputPath's extension with ext for plans without a date or a profile
+func GetPlanOutputPath(inputPath, outputPath string, p meal.Plan, ext string) string {
+	if outputPath != "" || p.Date.IsZero() || p.Profile.IsZero() {
+		return GetOutputPathWithExt(inputPath, outputPath, ext)
+	}
+	return filepath.Join(filepath.Dir(inputPath), FileName(p, ext))
+}
+
+// FileName returns the name of the day file of a plan: its date as DDMMYY followed by the slug of its profile,
+// e.g. "010126-anna-wege-1500kcal.md", so that the plans of several people for the same day can coexist.
+// Plans without a date are named "meals", like the files of js/extract-meals.js.
+func FileName(p meal.Plan, ext string) string {
+	name := "meals"
+	if !p.Date.IsZero() {
+		name = p.Date.Format(dayFileLayout)
+	}
+	if slug := p.Profile.Slug(); slug != "" {
+		name += "-" + slug
+	}
+	return name + ext
+}
+
+// ParseFile reads a day file and parses it according to its extension (XML, JSON or Markdown).
+// Plans without a date of their own take it from the file name.
+func ParseFile(path string) (meal.Plan, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	var parse func([]byte) (meal.Plan, error)
+	switch ext {
+	case ".xml":
+		parse = ParseXML
+	case ".json":
+		parse = ParseJSON
+	case ".md":
+		parse = ParseMarkdown
+	default:
+		return meal.Plan{}, fmt.Errorf("%w: %s", ErrUnsupportedFormat, ext)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return meal.Plan{}, err
+	}
+	mealPlan, err := parse(data)
+	if err != nil {
+		return meal.Plan{}, err
+	}
+	if date, ok := DateFromPath(path); ok && mealPlan.Date.IsZero() {
+		mealPlan.Date = date
+	}
+	return mealPlan, nil
+}
+
+// DateFromPath returns the day encoded at the start of a file name as DDMMYY, the naming used by js/extract-meals.js
+// and FileName
+func DateFromPath(path string) (time.Time, bool) {
+	base := filepath.Base(path)
+	if len(base) < len(dayFileLayout) {
+		return time.Time{}, false
+	}
+	date, err := time.Parse(dayFileLayout, base[:len(dayFileLayout)])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return date, true
+}
@@ -1,9 +1,12 @@
 package parser
 
 import (
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/toszr/dietician/meal"
 )
 
 func TestGetOutputPath(t *testing.T) {
@@ -35,3 +38,60 @@ func TestGetOutputPath(t *testing.T) {
 		assert.Equal(t, expected, GetOutputPath(inputPath, outputPath))
 	})
 }
+
+func TestGetPlanOutputPath(t *testing.T) {
+	plan := meal.Plan{
+		Date:    time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		Profile: meal.Profile{Person: "Anna", Variant: "Wege", Calories: 1500},
+	}
+
+	t.Run("named after the plan", func(t *testing.T) {
+		assert.Equal(t, filepath.Join("samples", "010126-anna-wege-1500kcal.md"), GetPlanOutputPath(filepath.Join("samples", "010126.json"), "", plan, ".md"))
+	})
+
+	t.Run("with output path", func(t *testing.T) {
+		assert.Equal(t, "output.md", GetPlanOutputPath("010126.json", "output.md", plan, ".md"))
+	})
+
+	t.Run("without a profile", func(t *testing.T) {
+		assert.Equal(t, "010126.md", GetPlanOutputPath("010126.json", "", meal.Plan{Date: plan.Date}, ".md"))
+	})
+
+	t.Run("without a date", func(t *testing.T) {
+		assert.Equal(t, "meals-anna.html", FileName(meal.Plan{Profile: meal.Profile{Person: "Anna"}}, ".html"))
+	})
+}
+
+func TestParseFile(t *testing.T) {
+	t.Run("unsupported extension", func(t *testing.T) {
+		_, err := ParseFile("test.txt")
+		assert.ErrorIs(t, err, ErrUnsupportedFormat)
+	})
+
+	t.Run("sources and markdown agree", func(t *testing.T) {
+		fromJSON, err := ParseFile(filepath.Join("..", "samples", "010126.json"))
+		assert.NoError(t, err)
+		fromMarkdown, err := ParseFile(filepath.Join("..", "samples", "010126.md"))
+		assert.NoError(t, err)
+		assert.Equal(t, fromJSON.FormatToMarkdown(), fromMarkdown.FormatToMarkdown())
+	})
+}
+
+func TestDateFromPath(t *testing.T) {
+	t.Run("day file", func(t *testing.T) {
+		date, ok := DateFromPath(filepath.Join("samples", "311225.json"))
+		assert.True(t, ok)
+		assert.Equal(t, time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC), date)
+	})
+
+	t.Run("day file with a profile", func(t *testing.T) {
+		date, ok := DateFromPath("010126-anna-wege-1500kcal.md")
+		assert.True(t, ok)
+		assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), date)
+	})
+
+	t.Run("not a day file", func(t *testing.T) {
+		_, ok := DateFromPath("meals.json")
+		assert.False(t, ok)
+	})
+}
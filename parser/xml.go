@@ -2,6 +2,7 @@ package parser
 
 import (
 	"encoding/xml"
+	"slices"
 	"strings"
 
 	"github.com/toszr/dietician/meal"
@@ -18,7 +19,7 @@ type Node struct {
 // ParseXMLToMarkdown parses XML data and returns Markdown output
 func ParseXMLToMarkdown(data []byte) (string, error) {
 	// Step 1: Parse XML to intermediate structure
-	mealPlan, err := parseXMLToMealPlan(data)
+	mealPlan, err := ParseXML(data)
 	if err != nil {
 		return "", err
 	}
@@ -27,8 +28,8 @@ func ParseXMLToMarkdown(data []byte) (string, error) {
 	return mealPlan.FormatToMarkdown(), nil
 }
 
-// parseXMLToMealPlan converts XML data to the structured meal.Plan format
-func parseXMLToMealPlan(data []byte) (meal.Plan, error) {
+// ParseXML converts XML data to the structured meal.Plan format
+func ParseXML(data []byte) (meal.Plan, error) {
 	var root Node
 	err := xml.Unmarshal(data, &root)
 	if err != nil {
@@ -36,19 +37,27 @@ func parseXMLToMealPlan(data []byte) (meal.Plan, error) {
 	}
 
 	var mealPlan meal.Plan
+	mealPlan.Profile.Variant, mealPlan.Profile.Calories = findVariant(root)
 	meals := findMeals(root)
 
 	for _, mealNode := range meals {
+		// Parse dishes for this meal
+		dishes := parseDishesFromMeal(mealNode)
+
 		// Get meal name
-		mealName := findFirstTextNode(mealNode)
+		mealName := findMealName(mealNode, dishes)
 		if mealName == "" {
 			continue
 		}
 
-		// Parse dishes for this meal
-		dishes := parseDishesFromMeal(mealNode)
+		// Tags of the meal section describe all its dishes
+		if tags := findTags(mealNode); len(tags) > 0 {
+			for i := range dishes {
+				dishes[i].Tags = appendTags(tags, dishes[i].Tags...)
+			}
+		}
 		// Always add the meal, even if it has no valid dishes
-		mealPlan = append(mealPlan, meal.Meal{
+		mealPlan.Meals = append(mealPlan.Meals, meal.Meal{
 			Name:   mealName,
 			Dishes: dishes,
 		})
@@ -97,21 +106,100 @@ func parseSingleDish(dishNode Node) meal.Dish {
 		dish.Ingredients = meal.ProcessIngredients(ingredients)
 	}
 
+	// Get badges of the dish and the instructions given with its name
+	dish.Tags = findTags(dishNode)
+	dish.ExtractInstructions()
+
 	return dish
 }
 
-func findFirstTextNode(n Node) string {
-	if strings.TrimSpace(n.Content) != "" {
-		return strings.TrimSpace(n.Content)
+// findMealName returns the first text of the meal header. The dishes and the tags of the meal
+// are not looked into, and the summary of the header, which repeats the name of the chosen dish,
+// is skipped.
+func findMealName(mealNode Node, dishes []meal.Dish) string {
+	summaries := map[string]bool{}
+	for _, d := range dishes {
+		summaries[d.Name] = true
+	}
+	return findFirstTextNode(mealNode, func(n Node) bool {
+		_, dataCy := getAttr(n, "data-cy")
+		return n.XMLName.Local == "section" || dataCy == "dish-tile__wrapper" || isTagContainer(n)
+	}, summaries)
+}
+
+// findFirstTextNode returns the first text, not looking into the nodes to skip nor returning
+// the excluded texts
+func findFirstTextNode(n Node, skip func(Node) bool, excluded map[string]bool) string {
+	if skip(n) {
+		return ""
+	}
+	if text := strings.TrimSpace(n.Content); text != "" && !excluded[text] {
+		return text
 	}
 	for _, c := range n.Nodes {
-		if t := findFirstTextNode(c); t != "" {
+		if t := findFirstTextNode(c, skip, excluded); t != "" {
 			return t
 		}
 	}
 	return ""
 }
 
+// tagContainerSuffixes end the class names of the provider's containers of tags and badges,
+// e.g. dishes__tags
+var tagContainerSuffixes = []string{"__tags", "__tag", "__badges", "__badge"}
+
+// isTagContainer reports whether the node holds tags or badges
+func isTagContainer(n Node) bool {
+	_, class := getAttr(n, "class")
+	for _, name := range strings.Fields(class) {
+		for _, suffix := range tagContainerSuffixes {
+			if strings.HasSuffix(name, suffix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// findTags returns the texts of the tags and badges of a node, not looking into its dishes
+func findTags(n Node) []string {
+	var tags []string
+	for _, c := range n.Nodes {
+		if _, val := getAttr(c, "data-cy"); val == "dish-tile__wrapper" {
+			continue
+		}
+		if isTagContainer(c) {
+			tags = appendTags(tags, tagTexts(c)...)
+			continue
+		}
+		tags = appendTags(tags, findTags(c)...)
+	}
+	return tags
+}
+
+// tagTexts returns the texts of the tags of a container, one per node with text
+func tagTexts(n Node) []string {
+	var texts []string
+	if text := strings.TrimSpace(n.Content); text != "" {
+		texts = append(texts, text)
+	}
+	for _, c := range n.Nodes {
+		texts = append(texts, tagTexts(c)...)
+	}
+	return texts
+}
+
+// appendTags appends the tags not yet in the list, leaving the list itself unchanged
+func appendTags(tags []string, more ...string) []string {
+	result := slices.Clip(tags)
+	for _, tag := range more {
+		if !slices.Contains(result, tag) {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
 // findFirstDishNameNode finds the first child node with a data-cy attribute (excluding wrappers/ingredients) and return its text
 func findFirstDishNameNode(n Node) string {
 	if ok, val := getAttr(n, "data-cy"); ok && val == "" {
@@ -151,6 +239,25 @@ func getAttr(n Node, key string) (bool, string) {
 	return false, ""
 }
 
+// findVariant returns the diet variant shown outside of the meals with its calorie level, e.g. "Wege 1500 kcal",
+// or zero calories if there is none. The calories of dishes are shown inside the meals, which are not looked into.
+func findVariant(n Node) (string, int) {
+	if _, val := getAttr(n, "data-cy"); val == "MealDropdownOptions_div" {
+		return "", 0
+	}
+	if text := strings.TrimSpace(n.Content); text != "" {
+		if name, calories := meal.ParseVariant(text); calories != 0 {
+			return name, calories
+		}
+	}
+	for _, c := range n.Nodes {
+		if name, calories := findVariant(c); calories != 0 {
+			return name, calories
+		}
+	}
+	return "", 0
+}
+
 // findMeals finds all meal nodes in the XML structure
 func findMeals(root Node) []Node {
 	var meals []Node
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/toszr/dietician/meal"
 )
 
 func TestParseXMLToMarkdown(t *testing.T) {
@@ -225,6 +226,88 @@ func TestParseXMLToMarkdown(t *testing.T) {
 	})
 }
 
+func TestParseXMLTags(t *testing.T) {
+	t.Run("meal tags and dish badges", func(t *testing.T) {
+		input := `<root>
+	<div data-cy="MealDropdownOptions_div">
+		<div><p><span>Obiad</span></p><p>Curry z ciecierzycą</p></div>
+		<section>
+			<div class="dishes__tags css-ay2nru"><span>wege</span><span>ostre</span></div>
+			<div data-cy="dish-tile__wrapper">
+				<div class="dish-tile__badge css-1">nowość</div>
+				<div data-cy="">Curry z ciecierzycą</div>
+				<span data-cy="IngredientsAndRecipes_span">ciecierzyca, mleczko kokosowe</span>
+			</div>
+			<div data-cy="dish-tile__wrapper">
+				<div data-cy="">Dal z soczewicy</div>
+			</div>
+		</section>
+	</div>
+</root>`
+
+		result, err := ParseXML([]byte(input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Obiad", result.Meals[0].Name)
+		assert.Equal(t, []string{"wege", "ostre", "nowość"}, result.Meals[0].Dishes[0].Tags)
+		assert.Equal(t, []string{"wege", "ostre"}, result.Meals[0].Dishes[1].Tags)
+	})
+
+	t.Run("meal summary is not the meal name", func(t *testing.T) {
+		input := `<root>
+	<div data-cy="MealDropdownOptions_div">
+		<div><p><span></span></p><p>Dal z soczewicy</p></div>
+		<p><span>Kolacja</span></p>
+		<div data-cy="dish-tile__wrapper">
+			<div data-cy="">Dal z soczewicy</div>
+		</div>
+	</div>
+</root>`
+
+		result, err := ParseXML([]byte(input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Kolacja", result.Meals[0].Name)
+		assert.Empty(t, result.Meals[0].Dishes[0].Tags)
+	})
+}
+
+func TestParseXMLVariant(t *testing.T) {
+	input := `<root>
+	<div data-cy="DietName_div"><span>Wege 1500 kcal</span></div>
+	<div data-cy="MealDropdownOptions_div">
+		<p><span>Obiad</span></p>
+		<div data-cy="dish-tile__wrapper">
+			<div data-cy="">Dal z soczewicy</div>
+			<span>471 kcal</span>
+		</div>
+	</div>
+</root>`
+
+	result, err := ParseXML([]byte(input))
+
+	assert.NoError(t, err)
+	assert.Equal(t, meal.Profile{Variant: "Wege", Calories: 1500}, result.Profile)
+}
+
+func TestParseXMLInstructions(t *testing.T) {
+	input := `<root>
+	<div data-cy="MealDropdownOptions_div">
+		<p><span>Śniadanie</span></p>
+		<div data-cy="dish-tile__wrapper">
+			<div data-cy="">Mini drożdżówki ze śliwkami (lekko podgrzać)</div>
+			<span data-cy="IngredientsAndRecipes_span">mąka pszenna, śliwki</span>
+		</div>
+	</div>
+</root>`
+
+	result, err := ParseXML([]byte(input))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Mini drożdżówki ze śliwkami", result.Meals[0].Dishes[0].Name)
+	assert.Equal(t, []string{"Lekko podgrzać"}, result.Meals[0].Dishes[0].Instructions)
+}
+
 func TestParseXMLToMarkdownWithComplexIngredients(t *testing.T) {
 	t.Run("complex ingredients with parentheses and special cases", func(t *testing.T) {
 		input := `<?xml version="1.0" encoding="UTF-8"?>
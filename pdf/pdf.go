@@ -0,0 +1,141 @@
+// Package pdf renders meal plans as an A4 PDF menu, one day per page, using an embedded font
+// so that Polish diacritics are printed correctly without any fonts installed on the system.
+package pdf
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/go-pdf/fpdf"
+
+	"github.com/toszr/dietician/meal"
+)
+
+//go:embed fonts/DejaVuSans.ttf
+var regularFont []byte
+
+//go:embed fonts/DejaVuSans-Bold.ttf
+var boldFont []byte
+
+const (
+	fontFamily  = "DejaVuSans"
+	margin      = 15.0
+	lineSpacing = 1.35
+	ptToMM      = 0.3528
+)
+
+// Render returns a PDF document with the plans of consecutive days, each day starting on a new page
+func Render(plans []meal.Plan, labels meal.Labels) ([]byte, error) {
+	doc := fpdf.New("P", "mm", "A4", "")
+	doc.SetMargins(margin, margin, margin)
+	doc.SetAutoPageBreak(true, margin)
+	doc.SetTitle(title(plans, labels), true)
+	doc.SetCreator("dietician", true)
+	doc.SetCreationDate(time.Unix(0, 0).UTC())
+	doc.AddUTF8FontFromBytes(fontFamily, "", regularFont)
+	doc.AddUTF8FontFromBytes(fontFamily, "B", boldFont)
+	doc.AliasNbPages("")
+	doc.SetFooterFunc(func() {
+		doc.SetY(-margin + 5)
+		doc.SetFont(fontFamily, "", 8)
+		doc.SetTextColor(120, 120, 120)
+		doc.CellFormat(0, 5, footer(doc.PageNo()), "", 0, "C", false, 0, "")
+	})
+
+	for _, plan := range plans {
+		renderDay(doc, plan, labels)
+	}
+	if len(plans) == 0 {
+		doc.AddPage()
+	}
+
+	var buf bytes.Buffer
+	if err := doc.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// renderDay writes a single day starting on a new page
+func renderDay(doc *fpdf.Fpdf, plan meal.Plan, labels meal.Labels) {
+	doc.AddPage()
+	doc.SetTextColor(47, 111, 79)
+	doc.SetFont(fontFamily, "B", 16)
+	heading := dayHeading(plan.Date, labels)
+	if !plan.Profile.IsZero() {
+		heading += " – " + plan.Profile.String()
+	}
+	doc.CellFormat(0, 9, heading, "", 1, "L", false, 0, "")
+	doc.Ln(2)
+
+	for _, m := range plan.Meals {
+		doc.SetTextColor(47, 111, 79)
+		doc.SetFont(fontFamily, "B", 12)
+		doc.CellFormat(0, 7, m.Name, "B", 1, "L", false, 0, "")
+		doc.Ln(1.5)
+
+		for _, dish := range m.Dishes {
+			doc.SetTextColor(0, 0, 0)
+			doc.SetFont(fontFamily, "B", 10)
+			doc.MultiCell(0, lineHeight(10), dish.Name, "", "L", false)
+			if len(dish.Tags) > 0 {
+				doc.SetTextColor(90, 90, 90)
+				doc.SetFont(fontFamily, "", 8)
+				doc.MultiCell(0, lineHeight(8), labels.Tags+": "+strings.Join(dish.Tags, ", "), "", "L", false)
+			}
+			for _, instruction := range dish.Instructions {
+				doc.SetTextColor(47, 111, 79)
+				doc.SetFont(fontFamily, "", 8)
+				doc.MultiCell(0, lineHeight(8), labels.Instructions+": "+instruction, "", "L", false)
+			}
+			if len(dish.Ingredients) > 0 {
+				doc.SetTextColor(90, 90, 90)
+				doc.SetFont(fontFamily, "", 8)
+				doc.MultiCell(0, lineHeight(8), strings.Join(dish.Ingredients, ", "), "", "L", false)
+			}
+			doc.Ln(1.5)
+		}
+		doc.Ln(2)
+	}
+}
+
+// footer returns the page number with the total, e.g. "2 / 7"; {nb} is replaced with the number of pages when the document is closed
+func footer(page int) string {
+	return fmt.Sprintf("%d / {nb}", page)
+}
+
+// lineHeight returns the height in mm of a line of text in the given font size
+func lineHeight(fontSize float64) float64 {
+	return fontSize * lineSpacing * ptToMM
+}
+
+// dayHeading returns e.g. "Czwartek, 01.01.2026", or a generic title for plans without a date
+func dayHeading(date time.Time, labels meal.Labels) string {
+	if date.IsZero() {
+		return labels.Menu
+	}
+	return capitalize(labels.Weekday(date)) + ", " + labels.FormatDate(date)
+}
+
+// title returns the document title covering the range of days
+func title(plans []meal.Plan, labels meal.Labels) string {
+	if len(plans) == 0 || plans[0].Date.IsZero() {
+		return labels.Menu
+	}
+	first, last := plans[0].Date, plans[len(plans)-1].Date
+	if first.Equal(last) {
+		return labels.Title(first)
+	}
+	return labels.Title(first) + " – " + labels.FormatDate(last)
+}
+
+// capitalize upper-cases the first letter, weekdays are lower case in running Polish text
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
@@ -0,0 +1,57 @@
+package pdf
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/toszr/dietician/meal"
+)
+
+func TestRender(t *testing.T) {
+	t.Run("one page per day with embedded font", func(t *testing.T) {
+		plans := []meal.Plan{
+			{Date: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Meals: []meal.Meal{
+				{Name: "Śniadanie", Dishes: []meal.Dish{{Name: "Jajecznica", Ingredients: []string{"Jaja kurze", "Masło"}}}},
+			}},
+			{Date: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), Meals: []meal.Meal{
+				{Name: "Obiad", Dishes: []meal.Dish{{Name: "Żurek", Ingredients: []string{"Kiełbasa", "Żur"}}}},
+			}},
+		}
+
+		result, err := Render(plans, meal.PolishLabels)
+
+		assert.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(result, []byte("%PDF-")))
+		assert.Equal(t, 2, bytes.Count(result, []byte("/Type /Page\n")))
+		assert.Equal(t, 2, bytes.Count(result, []byte("/FontFile2")))
+	})
+
+	t.Run("no plans", func(t *testing.T) {
+		result, err := Render(nil, meal.PolishLabels)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, bytes.Count(result, []byte("/Type /Page\n")))
+	})
+}
+
+func TestFooter(t *testing.T) {
+	assert.Equal(t, "1 / {nb}", footer(1))
+	assert.Equal(t, "12 / {nb}", footer(12))
+}
+
+func TestDayHeading(t *testing.T) {
+	assert.Equal(t, "Czwartek, 01.01.2026", dayHeading(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), meal.PolishLabels))
+	assert.Equal(t, "Jadłospis", dayHeading(time.Time{}, meal.PolishLabels))
+}
+
+func TestTitle(t *testing.T) {
+	plans := []meal.Plan{
+		{Date: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Date: time.Date(2026, 1, 7, 0, 0, 0, 0, time.UTC)},
+	}
+	assert.Equal(t, "Jadłospis 01.01.2026 – 07.01.2026", title(plans, meal.PolishLabels))
+	assert.Equal(t, "Jadłospis 01.01.2026", title(plans[:1], meal.PolishLabels))
+}
@@ -0,0 +1,107 @@
+// Package plants classifies ingredients as plants with a bundled dictionary and counts how many
+// different plants a menu has, for the goal of eating 30 or more plants a week.
+package plants
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/toszr/dietician/meal"
+)
+
+//go:embed data/plants.txt
+var plantsData string
+
+// Default is the catalog of plants loaded from data/plants.txt
+var Default = mustParseCatalog(plantsData)
+
+// Plant is a plant species, or a group of species eaten alike such as mushrooms
+type Plant struct {
+	Name     string `json:"name"`
+	Category string `json:"category"`
+}
+
+// Catalog is a list of plants with the terms that identify them in ingredient names
+type Catalog struct {
+	Plants     []Plant
+	Categories []string
+	// terms[i] are the terms of Plants[i]
+	terms meal.TermCatalog
+}
+
+// ParseCatalog reads a catalog in the format of data/plants.txt
+func ParseCatalog(data string) (Catalog, error) {
+	var c Catalog
+	category := ""
+	for i, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if name, ok := strings.CutPrefix(line, "["); ok {
+			category = strings.TrimSpace(strings.TrimSuffix(name, "]"))
+			c.Categories = append(c.Categories, category)
+			continue
+		}
+		if category == "" {
+			return Catalog{}, fmt.Errorf("line %d: plant outside of a category", i+1)
+		}
+		if !strings.Contains(line, ":") {
+			return Catalog{}, fmt.Errorf("line %d: expected \"plant: terms\"", i+1)
+		}
+		terms, err := meal.ParseTermCatalog(line)
+		if err != nil {
+			return Catalog{}, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		c.Plants = append(c.Plants, Plant{Name: terms[0].Name, Category: category})
+		c.terms = append(c.terms, terms[0])
+	}
+	return c, nil
+}
+
+func mustParseCatalog(data string) Catalog {
+	c, err := ParseCatalog(data)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Classify returns the plants named by an ingredient name, not looking into its composition
+func (c Catalog) Classify(name string) []Plant {
+	var plants []Plant
+	for i, category := range c.terms {
+		if category.Matches(name) {
+			plants = append(plants, c.Plants[i])
+		}
+	}
+	return plants
+}
+
+// Dish returns the plants in the ingredients of a dish and their components, in catalog order
+func (c Catalog) Dish(d meal.Dish) []Plant {
+	found := map[Plant]bool{}
+	var walk func(ings []meal.Ingredient)
+	walk = func(ings []meal.Ingredient) {
+		for _, ing := range ings {
+			for _, p := range c.Classify(ing.Name) {
+				found[p] = true
+			}
+			walk(ing.Components)
+		}
+	}
+	walk(meal.ParseIngredients(d.Ingredients))
+	return c.sorted(found)
+}
+
+// sorted returns the plants of a set in catalog order
+func (c Catalog) sorted(set map[Plant]bool) []Plant {
+	plants := make([]Plant, 0, len(set))
+	for _, p := range c.Plants {
+		if set[p] {
+			plants = append(plants, p)
+		}
+	}
+	return plants
+}
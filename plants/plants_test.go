@@ -0,0 +1,128 @@
+package plants
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/toszr/dietician/meal"
+)
+
+func names(plants []Plant) []string {
+	var result []string
+	for _, p := range plants {
+		result = append(result, p.Name)
+	}
+	return result
+}
+
+func TestParseCatalog(t *testing.T) {
+	t.Run("categories", func(t *testing.T) {
+		c, err := ParseCatalog("# comment\n[vegetables]\nmarchew: marchew, marchw\n\n[fruits]\njabłko: jabłk, -ocet jabłk\n")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"vegetables", "fruits"}, c.Categories)
+		assert.Equal(t, []Plant{{"marchew", "vegetables"}, {"jabłko", "fruits"}}, c.Plants)
+	})
+
+	t.Run("plant outside of a category", func(t *testing.T) {
+		_, err := ParseCatalog("marchew: marchew\n")
+
+		assert.EqualError(t, err, "line 1: plant outside of a category")
+	})
+
+	t.Run("missing terms", func(t *testing.T) {
+		_, err := ParseCatalog("[vegetables]\nmarchew\n")
+
+		assert.EqualError(t, err, "line 2: expected \"plant: terms\"")
+	})
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected []string
+	}{
+		{"Marchew", []string{"marchew"}},
+		{"Natka pietruszki", []string{"pietruszka"}},
+		{"Pestki dyni", []string{"pestki dyni"}},
+		{"Dynia hokkaido", []string{"dynia"}},
+		{"Pomidor malinowy", []string{"pomidor"}},
+		{"Pieczarki portobello", []string{"grzyby"}},
+		{"Ocet jabłkowy", nil},
+		{"Skrobia ziemniaczana", nil},
+		{"Makaron ryżowy", []string{"ryż"}},
+		{"Sos sojowy", nil},
+		{"Oliwki czarne", []string{"oliwki"}},
+		{"Oliwa z oliwek", nil},
+		{"Olej słonecznikowy", nil},
+		{"Olej lniany", nil},
+		{"Olej sojowy", nil},
+		{"Olej kokosowy", nil},
+		{"Lecytyna sojowa", nil},
+		{"Olej z pestek dyni", nil},
+		{"Olej sezamowy", nil},
+		{"Pestki słonecznika", []string{"słonecznik"}},
+		{"Siemię lniane", []string{"len"}},
+		{"Mleczko kokosowe", []string{"kokos"}},
+		{"Sól", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, names(Default.Classify(tt.name)))
+		})
+	}
+}
+
+func TestDish(t *testing.T) {
+	dish := meal.Dish{Ingredients: []string{"Bulion warzywny (woda, marchew, seler, cebula)", "Marchew", "Pieprz mielony"}}
+
+	assert.Equal(t, []string{"marchew", "cebula", "seler", "pieprz"}, names(Default.Dish(dish)))
+}
+
+func TestWeekly(t *testing.T) {
+	day := func(d int, ingredients ...string) meal.Plan {
+		return meal.Plan{
+			Date:  time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC),
+			Meals: []meal.Meal{{Name: "Obiad", Dishes: []meal.Dish{{Name: "Sałatka", Ingredients: ingredients}}}},
+		}
+	}
+	plans := []meal.Plan{
+		day(4, "Marchew", "Jabłko"),
+		day(5, "Marchew", "Pomidor", "Bazylia"),
+		day(6, "Pomidor"),
+		{Meals: []meal.Meal{{Name: "Obiad", Dishes: []meal.Dish{{Name: "Owsianka", Ingredients: []string{"Płatki owsiane"}}}}}},
+	}
+
+	r := Default.Weekly(plans, 2)
+
+	require.Len(t, r.Weeks, 2)
+	assert.Equal(t, "2025-12-29", r.Weeks[0].Start)
+	assert.Equal(t, []string{"marchew", "jabłko"}, names(r.Weeks[0].Plants))
+	assert.Equal(t, "2026-01-05", r.Weeks[1].Start)
+	assert.Equal(t, []string{"marchew", "pomidor", "bazylia"}, names(r.Weeks[1].Plants))
+	assert.False(t, r.Weeks[1].Days[0].Low)
+	assert.True(t, r.Weeks[1].Days[1].Low)
+
+	md := r.FormatToMarkdown(Default)
+	assert.Contains(t, md, "## Week of 2026-01-05: 3 plants (goal 30)\n\n| Day | Plants |  |\n| --- | --- | --- |\n| 2026-01-05 | 3 |  |\n| 2026-01-06 | 1 | **low** |\n")
+	assert.Contains(t, md, "- **vegetables** (2): marchew, pomidor\n- **herbs** (1): bazylia\n")
+}
+
+func TestWeeklyProfiles(t *testing.T) {
+	day := func(person string, ingredients ...string) meal.Plan {
+		return meal.Plan{
+			Date:    time.Date(2026, 1, 6, 0, 0, 0, 0, time.UTC),
+			Profile: meal.Profile{Person: person},
+			Meals:   []meal.Meal{{Name: "Obiad", Dishes: []meal.Dish{{Name: "Sałatka", Ingredients: ingredients}}}},
+		}
+	}
+
+	r := Default.Weekly([]meal.Plan{day("Anna", "Pomidor"), day("Piotr", "Jabłko")}, 2)
+
+	require.Len(t, r.Weeks, 1)
+	require.Len(t, r.Weeks[0].Days, 1)
+	assert.Equal(t, []string{"pomidor", "jabłko"}, names(r.Weeks[0].Days[0].Plants))
+	assert.False(t, r.Weeks[0].Days[0].Low)
+}
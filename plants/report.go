@@ -0,0 +1,140 @@
+package plants
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/toszr/dietician/meal"
+	"github.com/toszr/dietician/table"
+)
+
+// WeeklyGoal is the number of different plants recommended a week
+const WeeklyGoal = 30
+
+// dateLayout is the layout of dates in reports
+const dateLayout = "2006-01-02"
+
+// Report counts the different plants of every week
+type Report struct {
+	Goal int `json:"goal"`
+	// MinDaily is the number of plants below which a day is marked as low in diversity
+	MinDaily int    `json:"minDaily"`
+	Weeks    []Week `json:"weeks"`
+}
+
+// Week is a week starting on Monday with the plants of all its days
+type Week struct {
+	Start  string  `json:"start"`
+	Plants []Plant `json:"plants"`
+	Days   []Day   `json:"days"`
+}
+
+// Day is a day with its plants
+type Day struct {
+	Date   string  `json:"date"`
+	Plants []Plant `json:"plants"`
+	Low    bool    `json:"low,omitempty"`
+}
+
+// Weekly counts the plants in the dishes of the plans, sorted by date, by week. Plans of several profiles
+// for the same date make one day. Plans without a date are left out.
+func (c Catalog) Weekly(plans []meal.Plan, minDaily int) Report {
+	r := Report{Goal: WeeklyGoal, MinDaily: minDaily, Weeks: []Week{}}
+	byDish := map[string][]Plant{}
+	var weekPlants, dayPlants map[Plant]bool
+	for _, p := range plans {
+		if p.Date.IsZero() {
+			continue
+		}
+		start := weekStart(p.Date).Format(dateLayout)
+		if len(r.Weeks) == 0 || r.Weeks[len(r.Weeks)-1].Start != start {
+			if len(r.Weeks) > 0 {
+				r.Weeks[len(r.Weeks)-1].Plants = c.sorted(weekPlants)
+			}
+			r.Weeks = append(r.Weeks, Week{Start: start})
+			weekPlants = map[Plant]bool{}
+		}
+		week := &r.Weeks[len(r.Weeks)-1]
+		date := p.Date.Format(dateLayout)
+		if len(week.Days) == 0 || week.Days[len(week.Days)-1].Date != date {
+			week.Days = append(week.Days, Day{Date: date})
+			dayPlants = map[Plant]bool{}
+		}
+
+		for _, m := range p.Meals {
+			for _, d := range m.Dishes {
+				// Dishes recur with the same ingredients, so they are classified once
+				key := meal.CanonicalName(d.Name) + "\x00" + strings.Join(d.Ingredients, "\x00")
+				plants, ok := byDish[key]
+				if !ok {
+					plants = c.Dish(d)
+					byDish[key] = plants
+				}
+				for _, plant := range plants {
+					dayPlants[plant] = true
+					weekPlants[plant] = true
+				}
+			}
+		}
+		day := &week.Days[len(week.Days)-1]
+		day.Plants = c.sorted(dayPlants)
+		day.Low = len(day.Plants) < minDaily
+	}
+	if len(r.Weeks) > 0 {
+		r.Weeks[len(r.Weeks)-1].Plants = c.sorted(weekPlants)
+	}
+	return r
+}
+
+// weekStart returns the Monday of the week of a date
+func weekStart(date time.Time) time.Time {
+	return date.AddDate(0, 0, -(int(date.Weekday())+6)%7)
+}
+
+// FormatToMarkdown renders every week with its days, marking low days, and its plants by category
+func (r Report) FormatToMarkdown(c Catalog) string {
+	var sb strings.Builder
+	sb.WriteString("# Plant diversity\n")
+	for _, w := range r.Weeks {
+		sb.WriteString("\n## Week of " + w.Start + ": " + strconv.Itoa(len(w.Plants)) + " plants")
+		if len(w.Plants) >= r.Goal {
+			sb.WriteString(" ✓")
+		} else {
+			sb.WriteString(" (goal " + strconv.Itoa(r.Goal) + ")")
+		}
+		sb.WriteString("\n\n")
+
+		days := table.Table{Header: []string{"Day", "Plants", ""}}
+		for _, d := range w.Days {
+			low := ""
+			if d.Low {
+				low = "**low**"
+			}
+			days.Rows = append(days.Rows, []string{d.Date, strconv.Itoa(len(d.Plants)), low})
+		}
+		sb.Write(days.FormatToMarkdown())
+		sb.WriteString("\n")
+
+		byCategory := map[string][]string{}
+		for _, p := range w.Plants {
+			byCategory[p.Category] = append(byCategory[p.Category], p.Name)
+		}
+		for _, category := range c.Categories {
+			if names := byCategory[category]; len(names) > 0 {
+				sb.WriteString("- **" + category + "** (" + strconv.Itoa(len(names)) + "): " + strings.Join(names, ", ") + "\n")
+			}
+		}
+	}
+	return sb.String()
+}
+
+// FormatToJSON renders the report as JSON
+func (r Report) FormatToJSON() (string, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
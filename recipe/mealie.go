@@ -0,0 +1,82 @@
+package recipe
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// MealieRecipe is a recipe in the JSON format of Mealie
+type MealieRecipe struct {
+	Name               string              `json:"name"`
+	Slug               string              `json:"slug"`
+	Description        string              `json:"description"`
+	RecipeCategory     []MealieCategory    `json:"recipeCategory"`
+	RecipeIngredient   []MealieIngredient  `json:"recipeIngredient"`
+	RecipeInstructions []MealieInstruction `json:"recipeInstructions"`
+	Notes              []MealieNote        `json:"notes"`
+	DateAdded          string              `json:"dateAdded,omitempty"`
+}
+
+// MealieCategory is a recipe category, the meal the dish was served as
+type MealieCategory struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// MealieIngredient is an ingredient line without amount, its text kept in the note
+type MealieIngredient struct {
+	Note          string  `json:"note"`
+	Display       string  `json:"display"`
+	Quantity      float64 `json:"quantity"`
+	DisableAmount bool    `json:"disableAmount"`
+}
+
+// MealieInstruction is a step of the recipe
+type MealieInstruction struct {
+	Text string `json:"text"`
+}
+
+// MealieNote is a titled note of the recipe
+type MealieNote struct {
+	Title string `json:"title"`
+	Text  string `json:"text"`
+}
+
+// Mealie converts the dish to a Mealie recipe
+func (e Entry) Mealie() MealieRecipe {
+	r := MealieRecipe{
+		Name:               e.Dish.Name,
+		Slug:               slug(e.Dish.Name),
+		Description:        e.description(),
+		RecipeCategory:     []MealieCategory{{Name: e.Meal, Slug: slug(e.Meal)}},
+		RecipeIngredient:   []MealieIngredient{},
+		RecipeInstructions: []MealieInstruction{},
+		Notes:              []MealieNote{},
+	}
+	if !e.Date.IsZero() {
+		r.DateAdded = e.Date.Format(dateLayout)
+	}
+	for _, text := range e.Dish.Ingredients {
+		text = strings.TrimSpace(text)
+		r.RecipeIngredient = append(r.RecipeIngredient, MealieIngredient{Note: text, Display: text, DisableAmount: true})
+	}
+	if notes := compositionNotes(e.ingredients()); notes != "" {
+		r.Notes = append(r.Notes, MealieNote{Title: compositionTitle, Text: notes})
+	}
+	return r
+}
+
+// FormatToMealie writes a Mealie migration archive with recipes/<slug>/<slug>.json for every dish
+func FormatToMealie(entries []Entry) ([]byte, error) {
+	var files []zipFile
+	for i, s := range uniqueSlugs(entries) {
+		r := entries[i].Mealie()
+		r.Slug = s
+		data, err := json.MarshalIndent(r, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, zipFile{name: "recipes/" + s + "/" + s + ".json", content: data})
+	}
+	return writeZip(files)
+}
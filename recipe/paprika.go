@@ -0,0 +1,69 @@
+package recipe
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// paprikaTimeLayout is the layout of timestamps in Paprika recipes
+const paprikaTimeLayout = "2006-01-02 15:04:05"
+
+// PaprikaRecipe is a recipe in the JSON format of Paprika
+type PaprikaRecipe struct {
+	UID         string   `json:"uid"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Ingredients string   `json:"ingredients"`
+	Directions  string   `json:"directions"`
+	Notes       string   `json:"notes"`
+	Categories  []string `json:"categories"`
+	Source      string   `json:"source"`
+	Servings    string   `json:"servings"`
+	Created     string   `json:"created"`
+	Hash        string   `json:"hash"`
+	PhotoData   *string  `json:"photo_data"`
+}
+
+// Paprika converts the dish to a Paprika recipe
+func (e Entry) Paprika() PaprikaRecipe {
+	r := PaprikaRecipe{
+		UID:         uid(e.Dish.Name),
+		Name:        e.Dish.Name,
+		Description: e.description(),
+		Ingredients: strings.Join(e.Dish.Ingredients, "\n"),
+		Categories:  []string{e.Meal},
+	}
+	if notes := compositionNotes(e.ingredients()); notes != "" {
+		r.Notes = compositionTitle + ":\n" + notes
+	}
+	if !e.Date.IsZero() {
+		r.Created = e.Date.Format(paprikaTimeLayout)
+	}
+	r.Hash = fmt.Sprintf("%X", sha256.Sum256([]byte(r.Name+"\n"+r.Ingredients+"\n"+r.Notes)))
+	return r
+}
+
+// FormatToPaprika writes a .paprikarecipes archive: a zip of gzipped JSON recipes, one per dish
+func FormatToPaprika(entries []Entry) ([]byte, error) {
+	var files []zipFile
+	for i, s := range uniqueSlugs(entries) {
+		data, err := json.Marshal(entries[i].Paprika())
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(data); err != nil {
+			return nil, err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+		files = append(files, zipFile{name: s + ".paprikarecipe", content: buf.Bytes()})
+	}
+	return writeZip(files)
+}
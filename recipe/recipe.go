@@ -0,0 +1,190 @@
+// Package recipe exports dishes to the formats of self-hosted recipe managers: Mealie, Paprika and Tandoor.
+// The menus carry no amounts or directions, so every ingredient is exported as a note-only line
+// in the order of the menu, and the composition given in parentheses is kept in the recipe notes.
+package recipe
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/toszr/dietician/meal"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+)
+
+// compositionTitle is the title of the note listing the composition of composite ingredients
+const compositionTitle = "Skład"
+
+// dateLayout is the layout of dates in exported recipes
+const dateLayout = "2006-01-02"
+
+// Entry is a dish together with the meal and day it was served on
+type Entry struct {
+	Dish meal.Dish
+	Meal string
+	Date time.Time
+}
+
+// Entries returns the dishes whose names contain query, ignoring case, or all dishes if query is empty.
+// A dish served several times is exported once, with the latest ingredients.
+func Entries(plans []meal.Plan, query string) []Entry {
+	query = meal.CanonicalName(query)
+	var entries []Entry
+	index := map[string]int{}
+	for _, p := range plans {
+		for _, m := range p.Meals {
+			for _, d := range m.Dishes {
+				name := meal.CanonicalName(d.Name)
+				if !strings.Contains(name, query) {
+					continue
+				}
+				entry := Entry{Dish: d, Meal: m.Name, Date: p.Date}
+				if i, ok := index[name]; ok {
+					if !entry.Date.Before(entries[i].Date) {
+						entries[i] = entry
+					}
+					continue
+				}
+				index[name] = len(entries)
+				entries = append(entries, entry)
+			}
+		}
+	}
+	return entries
+}
+
+// description tells where the dish comes from, e.g. "Obiad, 01.01.2026"
+func (e Entry) description() string {
+	if e.Date.IsZero() {
+		return e.Meal
+	}
+	return e.Meal + ", " + meal.PolishLabels.FormatDate(e.Date)
+}
+
+// ingredients returns the parsed ingredients of the dish in the order of the menu
+func (e Entry) ingredients() []meal.Ingredient {
+	return meal.ParseIngredients(e.Dish.Ingredients)
+}
+
+// composition writes out the components of an ingredient, e.g. "woda, mąka pszenna 40% (typ 500)"
+func composition(ing meal.Ingredient) string {
+	parts := make([]string, 0, len(ing.Components))
+	for _, c := range ing.Components {
+		part := c.Name
+		if c.Percentage > 0 {
+			part += " " + strconv.FormatFloat(c.Percentage, 'f', -1, 64) + "%"
+		}
+		if len(c.Components) > 0 {
+			part += " (" + composition(c) + ")"
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// compositionNotes lists the composition of every composite ingredient as a nested Markdown list,
+// or returns an empty string if no ingredient has components
+func compositionNotes(ings []meal.Ingredient) string {
+	var sb strings.Builder
+	var write func(ings []meal.Ingredient, indent string)
+	write = func(ings []meal.Ingredient, indent string) {
+		for _, ing := range ings {
+			line := ing.Name
+			if ing.Percentage > 0 {
+				line += " " + strconv.FormatFloat(ing.Percentage, 'f', -1, 64) + "%"
+			}
+			sb.WriteString(indent + "- " + line + "\n")
+			write(ing.Components, indent+"  ")
+		}
+	}
+	for _, ing := range ings {
+		if len(ing.Components) == 0 {
+			continue
+		}
+		sb.WriteString(ing.Name + ":\n")
+		write(ing.Components, "")
+	}
+	return sb.String()
+}
+
+// slug turns a dish name into an ASCII file name, e.g. "Żurek śląski" -> "zurek-slaski"
+func slug(name string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range norm.NFD.String(cases.Lower(language.Polish).String(name)) {
+		switch {
+		case r == 'ł':
+			r = 'l'
+		case unicode.Is(unicode.Mn, r):
+			continue
+		}
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			if dash && sb.Len() > 0 {
+				sb.WriteByte('-')
+			}
+			sb.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	if sb.Len() == 0 {
+		return "recipe"
+	}
+	return sb.String()
+}
+
+// uniqueSlugs returns a distinct slug for every entry
+func uniqueSlugs(entries []Entry) []string {
+	slugs := make([]string, 0, len(entries))
+	used := map[string]bool{}
+	for _, e := range entries {
+		base := slug(e.Dish.Name)
+		s := base
+		for n := 2; used[s]; n++ {
+			s = fmt.Sprintf("%s-%d", base, n)
+		}
+		used[s] = true
+		slugs = append(slugs, s)
+	}
+	return slugs
+}
+
+// uid derives a stable identifier in UUID format from the dish name, so re-exporting
+// a dish updates the recipe instead of duplicating it
+func uid(name string) string {
+	sum := sha256.Sum256([]byte(meal.CanonicalName(name)))
+	return strings.ToUpper(fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16]))
+}
+
+// zipFile is a file of an exported archive
+type zipFile struct {
+	name    string
+	content []byte
+}
+
+// writeZip packs files into a zip archive
+func writeZip(files []zipFile) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range files {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: f.name, Method: zip.Deflate, Modified: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(f.content); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,144 @@
+package recipe
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/toszr/dietician/meal"
+)
+
+var sampleEntry = Entry{
+	Dish: meal.Dish{
+		Name:        "Żurek śląski",
+		Ingredients: []string{"Zakwas", "Bulion warzywny (woda, marchew 20%, seler (korzeń))", "Sól"},
+	},
+	Meal: "Obiad",
+	Date: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+}
+
+// readZip returns the contents of the files in a zip archive by name
+func readZip(t *testing.T, data []byte) map[string][]byte {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	files := map[string][]byte{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[f.Name] = content
+	}
+	return files
+}
+
+func TestEntries(t *testing.T) {
+	plans := []meal.Plan{
+		{Date: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), Meals: []meal.Meal{{Name: "Obiad", Dishes: []meal.Dish{{Name: "Zupa", Ingredients: []string{"Woda", "Sól"}}}}}},
+		{Date: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Meals: []meal.Meal{{Name: "Kolacja", Dishes: []meal.Dish{
+			{Name: "ZUPA", Ingredients: []string{"Woda"}},
+			{Name: "Kanapka"},
+		}}}},
+	}
+
+	t.Run("latest serving of every dish", func(t *testing.T) {
+		entries := Entries(plans, "")
+		require.Len(t, entries, 2)
+		assert.Equal(t, "Obiad", entries[0].Meal)
+		assert.Equal(t, []string{"Woda", "Sól"}, entries[0].Dish.Ingredients)
+		assert.Equal(t, "Kanapka", entries[1].Dish.Name)
+	})
+
+	t.Run("query", func(t *testing.T) {
+		entries := Entries(plans, "kanap")
+		require.Len(t, entries, 1)
+		assert.Equal(t, "Kanapka", entries[0].Dish.Name)
+	})
+}
+
+func TestComposition(t *testing.T) {
+	ing := meal.ParseIngredient("Bulion warzywny (woda, marchew 20%, seler (korzeń))")
+	assert.Equal(t, "woda, marchew 20%, seler (korzeń)", composition(ing))
+	assert.Equal(t, "Bulion warzywny:\n- woda\n- marchew 20%\n- seler\n  - korzeń\n", compositionNotes([]meal.Ingredient{ing}))
+	assert.Empty(t, compositionNotes(meal.ParseIngredients([]string{"Sól"})))
+}
+
+func TestSlug(t *testing.T) {
+	assert.Equal(t, "zurek-slaski", slug("Żurek śląski"))
+	assert.Equal(t, "ii-sniadanie", slug("II śniadanie"))
+	assert.Equal(t, "recipe", slug("!!!"))
+	assert.Equal(t, []string{"zupa", "zupa-2"}, uniqueSlugs([]Entry{{Dish: meal.Dish{Name: "Zupa"}}, {Dish: meal.Dish{Name: "zupa!"}}}))
+}
+
+func TestFormatToMealie(t *testing.T) {
+	data, err := FormatToMealie([]Entry{sampleEntry})
+	require.NoError(t, err)
+
+	files := readZip(t, data)
+	require.Contains(t, files, "recipes/zurek-slaski/zurek-slaski.json")
+	var r MealieRecipe
+	require.NoError(t, json.Unmarshal(files["recipes/zurek-slaski/zurek-slaski.json"], &r))
+
+	assert.Equal(t, "Żurek śląski", r.Name)
+	assert.Equal(t, "Obiad, 02.01.2026", r.Description)
+	assert.Equal(t, []MealieCategory{{Name: "Obiad", Slug: "obiad"}}, r.RecipeCategory)
+	require.Len(t, r.RecipeIngredient, 3)
+	assert.Equal(t, MealieIngredient{
+		Note:          "Bulion warzywny (woda, marchew 20%, seler (korzeń))",
+		Display:       "Bulion warzywny (woda, marchew 20%, seler (korzeń))",
+		DisableAmount: true,
+	}, r.RecipeIngredient[1])
+	assert.Equal(t, []MealieNote{{Title: "Skład", Text: "Bulion warzywny:\n- woda\n- marchew 20%\n- seler\n  - korzeń\n"}}, r.Notes)
+}
+
+func TestFormatToPaprika(t *testing.T) {
+	data, err := FormatToPaprika([]Entry{sampleEntry})
+	require.NoError(t, err)
+
+	files := readZip(t, data)
+	require.Contains(t, files, "zurek-slaski.paprikarecipe")
+	zr, err := gzip.NewReader(bytes.NewReader(files["zurek-slaski.paprikarecipe"]))
+	require.NoError(t, err)
+	var r PaprikaRecipe
+	require.NoError(t, json.NewDecoder(zr).Decode(&r))
+
+	assert.Equal(t, "Żurek śląski", r.Name)
+	assert.Equal(t, "Zakwas\nBulion warzywny (woda, marchew 20%, seler (korzeń))\nSól", r.Ingredients)
+	assert.Equal(t, "Skład:\nBulion warzywny:\n- woda\n- marchew 20%\n- seler\n  - korzeń\n", r.Notes)
+	assert.Equal(t, []string{"Obiad"}, r.Categories)
+	assert.Equal(t, "2026-01-02 00:00:00", r.Created)
+	assert.Regexp(t, `^[0-9A-F]{8}-[0-9A-F]{4}-[0-9A-F]{4}-[0-9A-F]{4}-[0-9A-F]{12}$`, r.UID)
+	assert.Equal(t, uid("ŻUREK  śląski"), r.UID, "the identifier does not depend on case and spacing")
+	assert.Len(t, r.Hash, 64)
+}
+
+func TestFormatToTandoor(t *testing.T) {
+	data, err := FormatToTandoor([]Entry{sampleEntry})
+	require.NoError(t, err)
+
+	files := readZip(t, data)
+	require.Contains(t, files, "1.zip")
+	inner := readZip(t, files["1.zip"])
+	require.Contains(t, inner, "recipe.json")
+	var r TandoorRecipe
+	require.NoError(t, json.Unmarshal(inner["recipe.json"], &r))
+
+	assert.Equal(t, "Żurek śląski", r.Name)
+	assert.Equal(t, []TandoorKeyword{{Name: "Obiad"}}, r.Keywords)
+	require.Len(t, r.Steps, 1)
+	require.Len(t, r.Steps[0].Ingredients, 3)
+	assert.Equal(t, TandoorIngredient{
+		Food:     TandoorFood{Name: "Bulion warzywny"},
+		Note:     "woda, marchew 20%, seler (korzeń)",
+		Order:    1,
+		NoAmount: true,
+	}, r.Steps[0].Ingredients[1])
+}
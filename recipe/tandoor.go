@@ -0,0 +1,85 @@
+package recipe
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// TandoorRecipe is a recipe in the export format of Tandoor
+type TandoorRecipe struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Keywords    []TandoorKeyword `json:"keywords"`
+	Steps       []TandoorStep    `json:"steps"`
+	WorkingTime int              `json:"working_time"`
+	WaitingTime int              `json:"waiting_time"`
+	Internal    bool             `json:"internal"`
+	Servings    int              `json:"servings"`
+}
+
+// TandoorKeyword is a keyword of the recipe, the meal the dish was served as
+type TandoorKeyword struct {
+	Name string `json:"name"`
+}
+
+// TandoorStep is a step of the recipe with the ingredients it uses
+type TandoorStep struct {
+	Instruction          string              `json:"instruction"`
+	Ingredients          []TandoorIngredient `json:"ingredients"`
+	Order                int                 `json:"order"`
+	ShowIngredientsTable bool                `json:"show_ingredients_table"`
+}
+
+// TandoorIngredient is an ingredient without amount; the composition is kept in its note
+type TandoorIngredient struct {
+	Food     TandoorFood `json:"food"`
+	Unit     *string     `json:"unit"`
+	Amount   float64     `json:"amount"`
+	Note     string      `json:"note"`
+	Order    int         `json:"order"`
+	IsHeader bool        `json:"is_header"`
+	NoAmount bool        `json:"no_amount"`
+}
+
+// TandoorFood is the food of an ingredient
+type TandoorFood struct {
+	Name string `json:"name"`
+}
+
+// Tandoor converts the dish to a Tandoor recipe with a single step holding all ingredients
+func (e Entry) Tandoor() TandoorRecipe {
+	step := TandoorStep{Ingredients: []TandoorIngredient{}, ShowIngredientsTable: true}
+	for i, ing := range e.ingredients() {
+		step.Ingredients = append(step.Ingredients, TandoorIngredient{
+			Food:     TandoorFood{Name: ing.Name},
+			Note:     composition(ing),
+			Order:    i,
+			NoAmount: true,
+		})
+	}
+	return TandoorRecipe{
+		Name:        e.Dish.Name,
+		Description: e.description(),
+		Keywords:    []TandoorKeyword{{Name: e.Meal}},
+		Steps:       []TandoorStep{step},
+		Internal:    true,
+		Servings:    1,
+	}
+}
+
+// FormatToTandoor writes a Tandoor export archive: a zip holding one zip with recipe.json per dish
+func FormatToTandoor(entries []Entry) ([]byte, error) {
+	var files []zipFile
+	for i, e := range entries {
+		data, err := json.MarshalIndent(e.Tandoor(), "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		inner, err := writeZip([]zipFile{{name: "recipe.json", content: data}})
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, zipFile{name: fmt.Sprintf("%d.zip", i+1), content: inner})
+	}
+	return writeZip(files)
+}
@@ -0,0 +1,251 @@
+// Package rotation finds dishes that recur in the menus, matching them by name and ingredients,
+// and estimates the period after which the provider repeats its menu.
+package rotation
+
+import (
+	"sort"
+	"time"
+	"unicode/utf8"
+
+	"github.com/toszr/dietician/meal"
+	"github.com/toszr/dietician/search"
+)
+
+// Threshold is the lowest similarity at which two dishes are taken to be the same dish
+const Threshold = 0.7
+
+// day is the length of a day, intervals are counted in whole days
+const day = 24 * time.Hour
+
+// Serving is a dish served on a day
+type Serving struct {
+	Date time.Time
+	Meal string
+	Dish meal.Dish
+}
+
+// Group is a dish with all its servings, in date order
+type Group struct {
+	Servings []Serving
+	// Intervals are the numbers of days between consecutive days the dish was served on
+	Intervals []int
+	// Changes counts the servings whose ingredients differ from the previous serving
+	Changes int
+}
+
+// Name returns the name of the latest serving
+func (g Group) Name() string {
+	return g.Servings[len(g.Servings)-1].Dish.Name
+}
+
+// Last returns the latest serving
+func (g Group) Last() Serving {
+	return g.Servings[len(g.Servings)-1]
+}
+
+// Report is the result of the rotation analysis
+type Report struct {
+	// Period is the most common recurrence interval in days, or 0 if no dish recurs
+	Period int
+	// Support is the share of all intervals equal to Period
+	Support float64
+	// Groups are the dishes served on more than one day, the most frequent first
+	Groups []Group
+}
+
+// minBlockingTermLen is the shortest word of a dish name used to find candidate groups;
+// shorter words are mostly prepositions and conjunctions shared by unrelated dishes
+const minBlockingTermLen = 3
+
+// features are the sets dishes are compared by
+type features struct {
+	name        map[string]bool
+	ingredients map[string]bool
+}
+
+func featuresOf(d meal.Dish) features {
+	f := features{name: map[string]bool{}, ingredients: map[string]bool{}}
+	for _, t := range search.Terms(d.Name) {
+		f.name[t] = true
+	}
+	for _, ing := range meal.ParseIngredients(d.Ingredients) {
+		f.ingredients[meal.CanonicalName(ing.Name)] = true
+	}
+	return f
+}
+
+func (f features) similarity(other features) float64 {
+	return (jaccard(f.name, other.name) + jaccard(f.ingredients, other.ingredients)) / 2
+}
+
+// Similarity compares two dishes by the words of their names and their sets of ingredients,
+// from 0 for unrelated dishes to 1 for the same name and ingredients
+func Similarity(a, b meal.Dish) float64 {
+	return featuresOf(a).similarity(featuresOf(b))
+}
+
+// jaccard is the size of the intersection of two sets divided by the size of their union,
+// 1 for two empty sets
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	common := 0
+	for k := range a {
+		if b[k] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(a)+len(b)-common)
+}
+
+// Match groups the dishes of the plans, assigning each serving to the most similar group whose
+// latest serving shares a word of the name and reaches Threshold. Servings are taken in date order,
+// so a dish whose recipe drifts over time stays in one group.
+func Match(plans []meal.Plan) []Group {
+	plans = append([]meal.Plan{}, plans...)
+	sort.SliceStable(plans, func(i, j int) bool { return plans[i].Date.Before(plans[j].Date) })
+
+	var groups []Group
+	var latest []features
+	byTerm := map[string][]int{}
+	for _, p := range plans {
+		for _, m := range p.Meals {
+			for _, d := range m.Dishes {
+				f := featuresOf(d)
+				best, bestScore := -1, Threshold
+				seen := map[int]bool{}
+				for term := range f.name {
+					for _, g := range byTerm[term] {
+						if seen[g] {
+							continue
+						}
+						seen[g] = true
+						if score := latest[g].similarity(f); score >= bestScore {
+							best, bestScore = g, score
+						}
+					}
+				}
+				if best < 0 {
+					best = len(groups)
+					groups = append(groups, Group{})
+					latest = append(latest, f)
+					for term := range f.name {
+						if utf8.RuneCountInString(term) >= minBlockingTermLen {
+							byTerm[term] = append(byTerm[term], best)
+						}
+					}
+				}
+				groups[best].add(Serving{Date: p.Date, Meal: m.Name, Dish: d}, jaccard(latest[best].ingredients, f.ingredients) < 1)
+				latest[best] = f
+			}
+		}
+	}
+	return groups
+}
+
+// add appends a serving, recording the interval since the previous day and whether the ingredients changed
+func (g *Group) add(s Serving, changed bool) {
+	if len(g.Servings) > 0 {
+		last := g.Last()
+		if days := int(s.Date.Sub(last.Date) / day); days > 0 && !last.Date.IsZero() {
+			g.Intervals = append(g.Intervals, days)
+		}
+		if changed {
+			g.Changes++
+		}
+	}
+	g.Servings = append(g.Servings, s)
+}
+
+// Analyze matches the dishes of the plans and finds the rotation period
+func Analyze(plans []meal.Plan) Report {
+	var report Report
+	counts := map[int]int{}
+	total := 0
+	for _, g := range Match(plans) {
+		if len(g.Intervals) == 0 {
+			continue
+		}
+		report.Groups = append(report.Groups, g)
+		for _, interval := range g.Intervals {
+			counts[interval]++
+			total++
+		}
+	}
+	for interval, count := range counts {
+		if count > counts[report.Period] || (count == counts[report.Period] && interval < report.Period) {
+			report.Period = interval
+		}
+	}
+	if total > 0 {
+		report.Support = float64(counts[report.Period]) / float64(total)
+	}
+	sort.SliceStable(report.Groups, func(i, j int) bool {
+		return len(report.Groups[i].Servings) > len(report.Groups[j].Servings)
+	})
+	return report
+}
+
+// Next predicts the next day the dish will be served on, after its median interval,
+// or after the rotation period if the dish has not recurred yet
+func (g Group) Next(period int) time.Time {
+	interval := period
+	if len(g.Intervals) > 0 {
+		sorted := append([]int{}, g.Intervals...)
+		sort.Ints(sorted)
+		interval = sorted[len(sorted)/2]
+	}
+	if interval == 0 {
+		return time.Time{}
+	}
+	return g.Last().Date.AddDate(0, 0, interval)
+}
+
+// History finds earlier servings of dishes
+type History struct {
+	servings []Serving
+	features []features
+	byTerm   map[string][]int
+}
+
+// NewHistory indexes the dishes of the plans
+func NewHistory(plans []meal.Plan) *History {
+	h := &History{byTerm: map[string][]int{}}
+	for _, p := range plans {
+		for _, m := range p.Meals {
+			for _, d := range m.Dishes {
+				f := featuresOf(d)
+				for term := range f.name {
+					if utf8.RuneCountInString(term) >= minBlockingTermLen {
+						h.byTerm[term] = append(h.byTerm[term], len(h.servings))
+					}
+				}
+				h.servings = append(h.servings, Serving{Date: p.Date, Meal: m.Name, Dish: d})
+				h.features = append(h.features, f)
+			}
+		}
+	}
+	return h
+}
+
+// Previous returns the latest serving of the dish before date, matched as by Match
+func (h *History) Previous(date time.Time, d meal.Dish) (Serving, bool) {
+	f := featuresOf(d)
+	best := -1
+	for term := range f.name {
+		for _, i := range h.byTerm[term] {
+			s := h.servings[i]
+			if !s.Date.Before(date) || (best >= 0 && !s.Date.After(h.servings[best].Date)) {
+				continue
+			}
+			if h.features[i].similarity(f) >= Threshold {
+				best = i
+			}
+		}
+	}
+	if best < 0 {
+		return Serving{}, false
+	}
+	return h.servings[best], true
+}
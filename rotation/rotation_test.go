@@ -0,0 +1,93 @@
+package rotation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/toszr/dietician/meal"
+)
+
+// date returns a day in January 2026, or later for days past 31
+func date(d int) time.Time {
+	return time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC)
+}
+
+// plan returns a day with a single meal of the given dishes
+func plan(d int, dishes ...meal.Dish) meal.Plan {
+	return meal.Plan{Date: date(d), Meals: []meal.Meal{{Name: "Obiad", Dishes: dishes}}}
+}
+
+var (
+	pierogi        = meal.Dish{Name: "Pierogi ruskie", Ingredients: []string{"Mąka pszenna", "Ziemniaki", "Twaróg", "Cebula"}}
+	pierogiXylitol = meal.Dish{Name: "Pierogi Ruskie", Ingredients: []string{"Mąka pszenna", "Ziemniaki", "Twaróg", "Cebula", "Ksylitol"}}
+	zupa           = meal.Dish{Name: "Zupa pomidorowa z ryżem", Ingredients: []string{"Pomidory", "Ryż", "Bulion warzywny"}}
+	zupaGrochowa   = meal.Dish{Name: "Zupa grochowa", Ingredients: []string{"Groch", "Boczek", "Bulion warzywny"}}
+)
+
+func TestSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, Similarity(pierogi, pierogi))
+	assert.Greater(t, Similarity(pierogi, pierogiXylitol), Threshold)
+	assert.Less(t, Similarity(zupa, zupaGrochowa), Threshold)
+}
+
+func TestMatch(t *testing.T) {
+	groups := Match([]meal.Plan{
+		plan(29, pierogiXylitol, zupaGrochowa),
+		plan(1, pierogi, zupa),
+		plan(15, pierogi),
+	})
+
+	require.Len(t, groups, 3)
+	assert.Equal(t, "Pierogi Ruskie", groups[0].Name())
+	assert.Len(t, groups[0].Servings, 3)
+	assert.Equal(t, []int{14, 14}, groups[0].Intervals)
+	assert.Equal(t, 1, groups[0].Changes)
+	assert.Equal(t, "Zupa pomidorowa z ryżem", groups[1].Name())
+	assert.Equal(t, "Zupa grochowa", groups[2].Name())
+}
+
+func TestAnalyze(t *testing.T) {
+	report := Analyze([]meal.Plan{
+		plan(1, pierogi, zupa),
+		plan(8, zupaGrochowa),
+		plan(15, pierogi, zupa),
+		plan(29, pierogi),
+		plan(30, zupa),
+	})
+
+	assert.Equal(t, 14, report.Period)
+	assert.Equal(t, 0.75, report.Support)
+	require.Len(t, report.Groups, 2, "dishes served once are left out")
+	assert.Equal(t, "Pierogi ruskie", report.Groups[0].Name())
+	assert.Equal(t, date(29+14), report.Groups[0].Next(report.Period))
+	assert.Equal(t, date(30+15), report.Groups[1].Next(report.Period), "median of 14 and 15 days")
+}
+
+func TestAnalyzeWithoutRecurrence(t *testing.T) {
+	report := Analyze([]meal.Plan{plan(1, pierogi)})
+
+	assert.Zero(t, report.Period)
+	assert.Zero(t, report.Support)
+	assert.Empty(t, report.Groups)
+	assert.True(t, Group{Servings: []Serving{{Date: date(1)}}}.Next(0).IsZero())
+}
+
+func TestHistoryPrevious(t *testing.T) {
+	history := NewHistory([]meal.Plan{
+		plan(1, pierogi),
+		plan(15, pierogiXylitol, zupa),
+		plan(29, pierogi),
+	})
+
+	previous, ok := history.Previous(date(20), pierogi)
+	require.True(t, ok)
+	assert.Equal(t, date(15), previous.Date)
+	assert.Equal(t, pierogiXylitol, previous.Dish)
+
+	_, ok = history.Previous(date(1), pierogi)
+	assert.False(t, ok, "only earlier days count")
+	_, ok = history.Previous(date(20), zupaGrochowa)
+	assert.False(t, ok)
+}
@@ -0,0 +1,170 @@
+// Package search finds dishes in meal plans with an inverted index over dish names and ingredients.
+// Matching ignores case and diacritics and applies light Polish stemming; queries can restrict
+// words to a field, e.g. "meal:obiad ingredient:tofu".
+package search
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/toszr/dietician/meal"
+)
+
+// Fields of the index that queries can filter on
+const (
+	FieldMeal       = "meal"
+	FieldDish       = "dish"
+	FieldIngredient = "ingredient"
+)
+
+// ErrEmptyQuery is returned for a query without any words
+var ErrEmptyQuery = errors.New("empty query")
+
+// Result is a dish matching the query, with whose plan it is in
+type Result struct {
+	Date    time.Time
+	Profile meal.Profile
+	Meal    string
+	Dish    string
+}
+
+// Index is an inverted index from terms of each field to the dishes containing them
+type Index struct {
+	docs     []Result
+	postings map[string]map[string][]int
+}
+
+// NewIndex indexes every dish of the plans. Ingredients include components of composite products.
+func NewIndex(plans []meal.Plan) *Index {
+	ix := &Index{postings: map[string]map[string][]int{
+		FieldMeal:       {},
+		FieldDish:       {},
+		FieldIngredient: {},
+	}}
+	for _, p := range plans {
+		for _, m := range p.Meals {
+			for _, d := range m.Dishes {
+				id := len(ix.docs)
+				ix.docs = append(ix.docs, Result{Date: p.Date, Profile: p.Profile, Meal: m.Name, Dish: d.Name})
+				ix.add(FieldMeal, m.Name, id)
+				ix.add(FieldDish, d.Name, id)
+				var walk func(ings []meal.Ingredient)
+				walk = func(ings []meal.Ingredient) {
+					for _, ing := range ings {
+						ix.add(FieldIngredient, ing.Name, id)
+						walk(ing.Components)
+					}
+				}
+				walk(meal.ParseIngredients(d.Ingredients))
+			}
+		}
+	}
+	return ix
+}
+
+// add records the terms of text in a field of a dish
+func (ix *Index) add(field, text string, id int) {
+	postings := ix.postings[field]
+	for _, term := range Terms(text) {
+		if ids := postings[term]; len(ids) == 0 || ids[len(ids)-1] != id {
+			postings[term] = append(ids, id)
+		}
+	}
+}
+
+// Clause is a word of a query and the fields it may match in
+type Clause struct {
+	Fields []string
+	Term   string
+}
+
+// ParseQuery splits a query into clauses. Words without a field match dish names or ingredients;
+// "field:value" matches only the field, and a quoted value such as ingredient:"mleko kokosowe"
+// requires all of its words in that field.
+func ParseQuery(query string) ([]Clause, error) {
+	var clauses []Clause
+	for _, token := range tokenize(query) {
+		fields := []string{FieldDish, FieldIngredient}
+		value := token
+		if name, rest, ok := strings.Cut(token, ":"); ok {
+			switch name {
+			case FieldMeal, FieldDish, FieldIngredient:
+				fields, value = []string{name}, rest
+			default:
+				return nil, fmt.Errorf("unknown field %q, expected %s, %s or %s", name, FieldMeal, FieldDish, FieldIngredient)
+			}
+		}
+		for _, term := range Terms(value) {
+			clauses = append(clauses, Clause{Fields: fields, Term: term})
+		}
+	}
+	if len(clauses) == 0 {
+		return nil, ErrEmptyQuery
+	}
+	return clauses, nil
+}
+
+// tokenize splits a query on spaces outside of double quotes and removes the quotes
+func tokenize(query string) []string {
+	var tokens []string
+	var token strings.Builder
+	quoted := false
+	for _, r := range query {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == ' ' && !quoted:
+			if token.Len() > 0 {
+				tokens = append(tokens, token.String())
+				token.Reset()
+			}
+		default:
+			token.WriteRune(r)
+		}
+	}
+	if token.Len() > 0 {
+		tokens = append(tokens, token.String())
+	}
+	return tokens
+}
+
+// Search returns the dishes matching every clause of the query, the most recent first
+func (ix *Index) Search(query string) ([]Result, error) {
+	clauses, err := ParseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched map[int]bool
+	for _, c := range clauses {
+		ids := map[int]bool{}
+		for _, field := range c.Fields {
+			for _, id := range ix.postings[field][c.Term] {
+				if matched == nil || matched[id] {
+					ids[id] = true
+				}
+			}
+		}
+		matched = ids
+	}
+
+	order := make([]int, 0, len(matched))
+	for id := range matched {
+		order = append(order, id)
+	}
+	sort.Slice(order, func(i, j int) bool {
+		a, b := ix.docs[order[i]], ix.docs[order[j]]
+		if !a.Date.Equal(b.Date) {
+			return a.Date.After(b.Date)
+		}
+		return order[i] < order[j]
+	})
+	results := make([]Result, 0, len(order))
+	for _, id := range order {
+		results = append(results, ix.docs[id])
+	}
+	return results, nil
+}
@@ -0,0 +1,83 @@
+package search
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/toszr/dietician/meal"
+)
+
+var testIndex = NewIndex([]meal.Plan{
+	{Date: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Meals: []meal.Meal{
+		{Name: "Obiad", Dishes: []meal.Dish{
+			{Name: "Pierogi ruskie", Ingredients: []string{"Mąka pszenna", "Ziemniaki"}},
+			{Name: "Tofu w sosie curry", Ingredients: []string{"Tofu naturalne", "Mleczko kokosowe (ekstrakt z kokosa, woda)"}},
+		}},
+	}},
+	{Date: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), Profile: meal.Profile{Person: "Anna", Variant: "wege"}, Meals: []meal.Meal{
+		{Name: "Kolacja", Dishes: []meal.Dish{
+			{Name: "Sałatka", Ingredients: []string{"Tofu wędzone", "Filet z piersi kurczaka (bez skóry)"}},
+		}},
+		{Name: "Obiad", Dishes: []meal.Dish{
+			{Name: "Zapiekanka z pierogami", Ingredients: []string{"Pierogi"}},
+		}},
+	}},
+})
+
+// dishes returns the names of the dishes found
+func dishes(t *testing.T, query string) []string {
+	t.Helper()
+	results, err := testIndex.Search(query)
+	require.NoError(t, err)
+	names := []string{}
+	for _, r := range results {
+		names = append(names, r.Dish)
+	}
+	return names
+}
+
+func TestSearch(t *testing.T) {
+	t.Run("inflected forms, most recent first", func(t *testing.T) {
+		assert.Equal(t, []string{"Zapiekanka z pierogami", "Pierogi ruskie"}, dishes(t, "pierogi"))
+	})
+
+	t.Run("results carry the profile", func(t *testing.T) {
+		results, err := testIndex.Search("pierogi")
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, meal.Profile{Person: "Anna", Variant: "wege"}, results[0].Profile)
+		assert.True(t, results[1].Profile.IsZero())
+	})
+
+	t.Run("diacritics are ignored", func(t *testing.T) {
+		assert.Equal(t, []string{"Sałatka"}, dishes(t, "skora"))
+		assert.Equal(t, []string{"Sałatka"}, dishes(t, "SALATKA"))
+	})
+
+	t.Run("all words must match", func(t *testing.T) {
+		assert.Equal(t, []string{"Sałatka"}, dishes(t, "tofu kurczak"))
+	})
+
+	t.Run("field filters", func(t *testing.T) {
+		assert.Equal(t, []string{"Tofu w sosie curry"}, dishes(t, "meal:obiad ingredient:tofu"))
+		assert.Equal(t, []string{"Zapiekanka z pierogami"}, dishes(t, "ingredient:pierogi"))
+		assert.Equal(t, []string{"Tofu w sosie curry"}, dishes(t, `ingredient:"mleczko kokosowe"`))
+	})
+
+	t.Run("components of composite products", func(t *testing.T) {
+		assert.Equal(t, []string{"Tofu w sosie curry"}, dishes(t, "ingredient:kokos"))
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		assert.Empty(t, dishes(t, "schabowy"))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := testIndex.Search("  ")
+		assert.ErrorIs(t, err, ErrEmptyQuery)
+		_, err = testIndex.Search("kind:zupa")
+		assert.EqualError(t, err, `unknown field "kind", expected meal, dish or ingredient`)
+	})
+}
@@ -0,0 +1,60 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+)
+
+// minStemLen is the shortest stem left after removing a suffix, in letters
+const minStemLen = 3
+
+// suffixes are the inflectional endings removed by Stem, longest first, without diacritics
+var suffixes = []string{
+	"ami", "ach", "ego", "emu", "ich", "ych", "ymi", "imi", "owi",
+	"ow", "om", "ie", "ej", "em",
+	"a", "e", "i", "y", "u", "o",
+}
+
+// Fold lowercases s and removes diacritics, so "Skóry" and "skory" compare equal
+func Fold(s string) string {
+	var sb strings.Builder
+	for _, r := range norm.NFD.String(cases.Lower(language.Polish).String(s)) {
+		switch {
+		case r == 'ł':
+			sb.WriteRune('l')
+		case unicode.Is(unicode.Mn, r):
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// Stem removes a Polish inflectional ending from a folded word, so that "pierogi", "pierogów"
+// and "pierogami" share the stem "pierog". It is a light stemmer: different words may share a stem,
+// which only widens the results.
+func Stem(word string) string {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(word, suffix) && utf8.RuneCountInString(word)-len(suffix) >= minStemLen {
+			return word[:len(word)-len(suffix)]
+		}
+	}
+	return word
+}
+
+// Terms splits text into folded and stemmed words
+func Terms(text string) []string {
+	words := strings.FieldsFunc(Fold(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := make([]string, 0, len(words))
+	for _, w := range words {
+		terms = append(terms, Stem(w))
+	}
+	return terms
+}
@@ -0,0 +1,29 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFold(t *testing.T) {
+	assert.Equal(t, "zolw skory lodz", Fold("Żółw SKÓRY łódź"))
+}
+
+func TestStem(t *testing.T) {
+	t.Run("inflected forms share a stem", func(t *testing.T) {
+		for _, word := range []string{"pierogi", "pierogow", "pierogami", "pierogach"} {
+			assert.Equal(t, "pierog", Stem(word), word)
+		}
+		assert.Equal(t, Stem("skora"), Stem("skory"))
+	})
+
+	t.Run("short words are kept", func(t *testing.T) {
+		assert.Equal(t, "ser", Stem("sera"))
+		assert.Equal(t, "ule", Stem("ule"), "a stem shorter than three letters is not split off")
+	})
+}
+
+func TestTerms(t *testing.T) {
+	assert.Equal(t, []string{"filet", "z", "piers", "kurczak", "bez", "skor"}, Terms("Filet z piersi kurczaka (bez skóry)"))
+}
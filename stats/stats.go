@@ -0,0 +1,210 @@
+// Package stats summarises the dishes and ingredients of a range of days: how varied the menu is,
+// which ingredients dominate it and how processed the products are.
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/toszr/dietician/meal"
+	"github.com/toszr/dietician/table"
+)
+
+// dateLayout is the layout of dates in reports
+const dateLayout = "2006-01-02"
+
+// Report is the summary of a range of days
+type Report struct {
+	// From and To are the first and the last dated day
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+	Days int    `json:"days"`
+	// Servings is the number of dishes served, DistinctDishes the number of dishes with different names
+	Servings       int `json:"servings"`
+	DistinctDishes int `json:"distinctDishes"`
+	// AverageIngredients is the mean number of top-level ingredients of a served dish
+	AverageIngredients float64 `json:"averageIngredients"`
+	// CompositeRatio is the share of top-level ingredients that are composite products,
+	// listed with their own ingredients in parentheses
+	CompositeRatio float64           `json:"compositeRatio"`
+	Meals          []MealCount       `json:"meals"`
+	Ingredients    []IngredientCount `json:"ingredients"`
+	LongestDishes  []DishLength      `json:"longestDishes"`
+}
+
+// MealCount is the number of dishes served at a meal, in total and with different names
+type MealCount struct {
+	Name     string `json:"mealName"`
+	Servings int    `json:"servings"`
+	Distinct int    `json:"distinct"`
+}
+
+// IngredientCount is the number of served dishes with a top-level ingredient
+type IngredientCount struct {
+	Name     string `json:"name"`
+	Servings int    `json:"servings"`
+}
+
+// DishLength is the length of the longest ingredient list a dish was served with
+type DishLength struct {
+	Name        string `json:"dishName"`
+	Ingredients int    `json:"ingredients"`
+}
+
+// Compute summarises the plans, listing at most top ingredients and dishes; a negative top lists none
+func Compute(plans []meal.Plan, top int) Report {
+	top = max(top, 0)
+	r := Report{Meals: []MealCount{}}
+
+	dishes := map[string]DishLength{}
+	meals := map[string]int{}
+	mealDishes := map[string]map[string]bool{}
+	ingredients := map[string]*IngredientCount{}
+	var total, composite int
+	// Plans of several profiles for the same date are one day; plans without a date are a day each
+	dates := map[string]bool{}
+	for _, p := range plans {
+		if p.Date.IsZero() {
+			r.Days++
+		} else {
+			date := p.Date.Format(dateLayout)
+			if !dates[date] {
+				dates[date] = true
+				r.Days++
+			}
+			if r.From == "" || date < r.From {
+				r.From = date
+			}
+			if date > r.To {
+				r.To = date
+			}
+		}
+		for _, m := range p.Meals {
+			mealKey := meal.CanonicalName(m.Name)
+			if _, ok := meals[mealKey]; !ok {
+				meals[mealKey] = len(r.Meals)
+				r.Meals = append(r.Meals, MealCount{Name: m.Name})
+				mealDishes[mealKey] = map[string]bool{}
+			}
+			mc := &r.Meals[meals[mealKey]]
+			for _, d := range m.Dishes {
+				key := meal.CanonicalName(d.Name)
+				r.Servings++
+				mc.Servings++
+				mealDishes[mealKey][key] = true
+
+				ings := meal.ParseIngredients(d.Ingredients)
+				if longest, ok := dishes[key]; !ok || len(ings) > longest.Ingredients {
+					dishes[key] = DishLength{Name: d.Name, Ingredients: len(ings)}
+				}
+				seen := map[string]bool{}
+				for _, ing := range ings {
+					total++
+					if len(ing.Components) > 0 {
+						composite++
+					}
+					name := meal.CanonicalName(ing.Name)
+					if name == "" || seen[name] {
+						continue
+					}
+					seen[name] = true
+					if ingredients[name] == nil {
+						ingredients[name] = &IngredientCount{Name: ing.Name}
+					}
+					ingredients[name].Servings++
+				}
+			}
+		}
+	}
+	for key, i := range meals {
+		r.Meals[i].Distinct = len(mealDishes[key])
+	}
+
+	r.DistinctDishes = len(dishes)
+	if r.Servings > 0 {
+		r.AverageIngredients = float64(total) / float64(r.Servings)
+	}
+	if total > 0 {
+		r.CompositeRatio = float64(composite) / float64(total)
+	}
+
+	r.Ingredients = make([]IngredientCount, 0, len(ingredients))
+	for _, ic := range ingredients {
+		r.Ingredients = append(r.Ingredients, *ic)
+	}
+	sort.Slice(r.Ingredients, func(i, j int) bool {
+		a, b := r.Ingredients[i], r.Ingredients[j]
+		if a.Servings != b.Servings {
+			return a.Servings > b.Servings
+		}
+		return a.Name < b.Name
+	})
+	r.Ingredients = r.Ingredients[:min(top, len(r.Ingredients))]
+
+	r.LongestDishes = make([]DishLength, 0, len(dishes))
+	for _, d := range dishes {
+		r.LongestDishes = append(r.LongestDishes, d)
+	}
+	sort.Slice(r.LongestDishes, func(i, j int) bool {
+		a, b := r.LongestDishes[i], r.LongestDishes[j]
+		if a.Ingredients != b.Ingredients {
+			return a.Ingredients > b.Ingredients
+		}
+		return a.Name < b.Name
+	})
+	r.LongestDishes = r.LongestDishes[:min(top, len(r.LongestDishes))]
+	return r
+}
+
+// FormatToMarkdown renders the report with a summary list and a table for every ranking
+func (r Report) FormatToMarkdown() string {
+	var sb strings.Builder
+	sb.WriteString("# Statistics")
+	if r.From != "" {
+		sb.WriteString(" " + r.From + " – " + r.To)
+	}
+	sb.WriteString("\n\n")
+	sb.WriteString("- Days: " + strconv.Itoa(r.Days) + "\n")
+	sb.WriteString("- Dishes served: " + strconv.Itoa(r.Servings) + "\n")
+	sb.WriteString("- Distinct dishes: " + strconv.Itoa(r.DistinctDishes) + "\n")
+	sb.WriteString(fmt.Sprintf("- Average ingredients per dish: %.1f\n", r.AverageIngredients))
+	sb.WriteString(fmt.Sprintf("- Composite products: %.1f%%\n", r.CompositeRatio*100))
+
+	meals := table.Table{Header: []string{"Meal", "Dishes", "Distinct"}}
+	for _, m := range r.Meals {
+		meals.Rows = append(meals.Rows, []string{m.Name, strconv.Itoa(m.Servings), strconv.Itoa(m.Distinct)})
+	}
+	ingredients := table.Table{Header: []string{"Ingredient", "Dishes"}}
+	for _, i := range r.Ingredients {
+		ingredients.Rows = append(ingredients.Rows, []string{i.Name, strconv.Itoa(i.Servings)})
+	}
+	longest := table.Table{Header: []string{"Dish", "Ingredients"}}
+	for _, d := range r.LongestDishes {
+		longest.Rows = append(longest.Rows, []string{d.Name, strconv.Itoa(d.Ingredients)})
+	}
+
+	for _, section := range []struct {
+		title string
+		table table.Table
+	}{
+		{"Meals", meals},
+		{"Most frequent ingredients", ingredients},
+		{"Longest ingredient lists", longest},
+	} {
+		sb.WriteString("\n## " + section.title + "\n\n")
+		sb.Write(section.table.FormatToMarkdown())
+	}
+	return sb.String()
+}
+
+// FormatToJSON renders the report as JSON
+func (r Report) FormatToJSON() (string, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
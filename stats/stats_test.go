@@ -0,0 +1,95 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/toszr/dietician/meal"
+)
+
+var plans = []meal.Plan{
+	{
+		Date: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+		Meals: []meal.Meal{
+			{Name: "Śniadanie", Dishes: []meal.Dish{
+				{Name: "Owsianka", Ingredients: []string{"Płatki owsiane", "Mleko 2%", "Jogurt naturalny (mleko, kultury bakterii)"}},
+			}},
+			{Name: "Obiad", Dishes: []meal.Dish{
+				{Name: "Pierogi ruskie", Ingredients: []string{"Mąka pszenna", "Ziemniaki", "Twaróg", "Mleko 2%"}},
+			}},
+		},
+	},
+	{
+		Date: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		Meals: []meal.Meal{
+			{Name: "śniadanie", Dishes: []meal.Dish{
+				{Name: "owsianka", Ingredients: []string{"Płatki owsiane"}},
+				{Name: "Kawa", Ingredients: []string{"Kawa"}},
+			}},
+		},
+	},
+}
+
+func TestCompute(t *testing.T) {
+	r := Compute(plans, 2)
+
+	assert.Equal(t, "2026-01-01", r.From)
+	assert.Equal(t, "2026-01-02", r.To)
+	assert.Equal(t, 2, r.Days)
+	assert.Equal(t, 4, r.Servings)
+	assert.Equal(t, 3, r.DistinctDishes)
+	assert.Equal(t, 9.0/4, r.AverageIngredients)
+	assert.Equal(t, 1.0/9, r.CompositeRatio)
+	assert.Equal(t, []MealCount{{Name: "Śniadanie", Servings: 3, Distinct: 2}, {Name: "Obiad", Servings: 1, Distinct: 1}}, r.Meals)
+	assert.Equal(t, []IngredientCount{{Name: "Mleko 2%", Servings: 2}, {Name: "Płatki owsiane", Servings: 2}}, r.Ingredients)
+	assert.Equal(t, []DishLength{{Name: "Pierogi ruskie", Ingredients: 4}, {Name: "Owsianka", Ingredients: 3}}, r.LongestDishes)
+}
+
+func TestFormat(t *testing.T) {
+	r := Compute(plans, 1)
+
+	t.Run("markdown", func(t *testing.T) {
+		md := r.FormatToMarkdown()
+
+		assert.Contains(t, md, "# Statistics 2026-01-01 – 2026-01-02\n\n- Days: 2\n")
+		assert.Contains(t, md, "- Average ingredients per dish: 2.2\n- Composite products: 11.1%\n")
+		assert.Contains(t, md, "## Meals\n\n| Meal | Dishes | Distinct |\n| --- | --- | --- |\n| Śniadanie | 3 | 2 |\n")
+		assert.Contains(t, md, "## Longest ingredient lists\n\n| Dish | Ingredients |\n| --- | --- |\n| Pierogi ruskie | 4 |\n")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		data, err := r.FormatToJSON()
+
+		require.NoError(t, err)
+		assert.Contains(t, data, "\"distinctDishes\": 3")
+		assert.Contains(t, data, "\"longestDishes\": [\n    {\n      \"dishName\": \"Pierogi ruskie\",\n      \"ingredients\": 4\n    }\n  ]")
+	})
+}
+
+func TestComputeEmpty(t *testing.T) {
+	r := Compute(nil, 10)
+
+	assert.Zero(t, r.AverageIngredients)
+	assert.Empty(t, r.From)
+	assert.Contains(t, r.FormatToMarkdown(), "# Statistics\n\n- Days: 0\n")
+}
+
+func TestComputeNegativeTop(t *testing.T) {
+	r := Compute(plans, -1)
+
+	assert.Empty(t, r.Ingredients)
+	assert.Empty(t, r.LongestDishes)
+}
+
+func TestComputeProfiles(t *testing.T) {
+	anna, piotr := plans[0], plans[0]
+	anna.Profile = meal.Profile{Person: "Anna"}
+	piotr.Profile = meal.Profile{Person: "Piotr"}
+
+	r := Compute([]meal.Plan{anna, piotr, {}}, 2)
+
+	assert.Equal(t, 2, r.Days)
+	assert.Equal(t, 4, r.Servings)
+}
@@ -0,0 +1,20 @@
+package table
+
+import (
+	"bytes"
+	"encoding/csv"
+)
+
+// FormatToCSV writes the table as CSV, or TSV when comma is a tab
+func (t Table) FormatToCSV(comma rune) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Comma = comma
+	if err := w.Write(t.Header); err != nil {
+		return nil, err
+	}
+	if err := w.WriteAll(t.Rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
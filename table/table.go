@@ -0,0 +1,131 @@
+// Package table flattens meal plans into rows for spreadsheets: one row per dish or one row per ingredient occurrence.
+package table
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/toszr/dietician/meal"
+)
+
+// dateLayout is the layout of the date column
+const dateLayout = "2006-01-02"
+
+// Dish flags reported in the flags column
+const (
+	FlagComposite  = "composite"
+	FlagIncomplete = "incomplete"
+	FlagSuspicious = "suspicious"
+)
+
+// Table is a header and rows of cells
+type Table struct {
+	Header []string
+	Rows   [][]string
+}
+
+// Dishes returns a table with a row per dish
+func Dishes(plans []meal.Plan) Table {
+	t := Table{Header: []string{"date", "meal", "dish", "ingredients", "flags", "diets", "tags"}}
+	for _, plan := range plans {
+		t.Rows = append(t.Rows, dishRows(plan)...)
+	}
+	return t
+}
+
+// Ingredients returns a table with a row per ingredient occurrence, including components of composite products.
+// The position of a component is the path from the top-level ingredient, e.g. 3.2.
+func Ingredients(plans []meal.Plan) Table {
+	t := Table{Header: []string{"date", "meal", "dish", "position", "ingredient", "parent", "percentage"}}
+	for _, plan := range plans {
+		t.Rows = append(t.Rows, ingredientRows(plan)...)
+	}
+	return t
+}
+
+// ByName returns the table builder for a table name, dishes or ingredients
+func ByName(name string) (func([]meal.Plan) Table, bool) {
+	switch name {
+	case "dishes":
+		return Dishes, true
+	case "ingredients":
+		return Ingredients, true
+	default:
+		return nil, false
+	}
+}
+
+func dishRows(plan meal.Plan) [][]string {
+	suspicious := map[string]bool{}
+	for _, d := range plan.Diagnose() {
+		if d.Ingredient != "" {
+			suspicious[d.Meal+"\x00"+d.Dish] = true
+		}
+	}
+
+	var rows [][]string
+	for _, m := range plan.Meals {
+		for _, dish := range m.Dishes {
+			var flags []string
+			for _, ing := range meal.ParseIngredients(dish.Ingredients) {
+				if len(ing.Components) > 0 {
+					flags = append(flags, FlagComposite)
+					break
+				}
+			}
+			if len(dish.Ingredients) == 0 {
+				flags = append(flags, FlagIncomplete)
+			}
+			if suspicious[m.Name+"\x00"+dish.Name] {
+				flags = append(flags, FlagSuspicious)
+			}
+			rows = append(rows, []string{
+				formatDate(plan),
+				m.Name,
+				dish.Name,
+				strconv.Itoa(len(dish.Ingredients)),
+				strings.Join(flags, ";"),
+				strings.Join(dish.Diets(), ";"),
+				strings.Join(dish.Tags, ";"),
+			})
+		}
+	}
+	return rows
+}
+
+func ingredientRows(plan meal.Plan) [][]string {
+	var rows [][]string
+	for _, m := range plan.Meals {
+		for _, dish := range m.Dishes {
+			prefix := []string{formatDate(plan), m.Name, dish.Name}
+			var walk func(ings []meal.Ingredient, position string, parent string)
+			walk = func(ings []meal.Ingredient, position string, parent string) {
+				for i, ing := range ings {
+					pos := strconv.Itoa(i + 1)
+					if position != "" {
+						pos = position + "." + pos
+					}
+					row := append(append([]string{}, prefix...), pos, ing.Name, parent, formatPercentage(ing.Percentage))
+					rows = append(rows, row)
+					walk(ing.Components, pos, ing.Name)
+				}
+			}
+			walk(meal.ParseIngredients(dish.Ingredients), "", "")
+		}
+	}
+	return rows
+}
+
+func formatDate(plan meal.Plan) string {
+	if plan.Date.IsZero() {
+		return ""
+	}
+	return plan.Date.Format(dateLayout)
+}
+
+func formatPercentage(p float64) string {
+	if p == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(p, 'f', -1, 64)
+}
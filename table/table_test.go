@@ -0,0 +1,99 @@
+package table
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/toszr/dietician/meal"
+)
+
+var testPlans = []meal.Plan{
+	{Date: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Meals: []meal.Meal{
+		{Name: "Śniadanie", Dishes: []meal.Dish{
+			{Name: "Sernik", Ingredients: []string{"Twaróg", "Wanilia (perły wanilii (62.5%), koncentrat 37.5%))"}, Tags: []string{"wege", "nowość"}},
+			{Name: "Herbata"},
+		}},
+	}},
+	{Date: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), Meals: []meal.Meal{
+		{Name: "Obiad", Dishes: []meal.Dish{
+			{Name: "Kotlet, ziemniaki", Ingredients: []string{"Mięso", "Kwas askorbinowy)"}},
+		}},
+	}},
+}
+
+func TestDishes(t *testing.T) {
+	expected := Table{
+		Header: []string{"date", "meal", "dish", "ingredients", "flags", "diets", "tags"},
+		Rows: [][]string{
+			{"2026-01-01", "Śniadanie", "Sernik", "2", "composite;suspicious", "vegetarian;pescatarian;gluten-free;nut-free", "wege;nowość"},
+			{"2026-01-01", "Śniadanie", "Herbata", "0", "incomplete", "", ""},
+			{"2026-01-02", "Obiad", "Kotlet, ziemniaki", "2", "suspicious", "gluten-free;lactose-free;nut-free", ""},
+		},
+	}
+
+	assert.Equal(t, expected, Dishes(testPlans))
+}
+
+func TestIngredients(t *testing.T) {
+	expected := Table{
+		Header: []string{"date", "meal", "dish", "position", "ingredient", "parent", "percentage"},
+		Rows: [][]string{
+			{"2026-01-01", "Śniadanie", "Sernik", "1", "Twaróg", "", ""},
+			{"2026-01-01", "Śniadanie", "Sernik", "2", "Wanilia", "", ""},
+			{"2026-01-01", "Śniadanie", "Sernik", "2.1", "perły wanilii", "Wanilia", "62.5"},
+			{"2026-01-01", "Śniadanie", "Sernik", "2.2", "koncentrat", "Wanilia", "37.5"},
+			{"2026-01-02", "Obiad", "Kotlet, ziemniaki", "1", "Mięso", "", ""},
+			{"2026-01-02", "Obiad", "Kotlet, ziemniaki", "2", "Kwas askorbinowy", "", ""},
+		},
+	}
+
+	assert.Equal(t, expected, Ingredients(testPlans))
+}
+
+func TestFormatToCSV(t *testing.T) {
+	t.Run("csv quotes commas", func(t *testing.T) {
+		result, err := Dishes(testPlans[1:]).FormatToCSV(',')
+
+		assert.NoError(t, err)
+		assert.Equal(t, "date,meal,dish,ingredients,flags,diets,tags\n2026-01-02,Obiad,\"Kotlet, ziemniaki\",2,suspicious,gluten-free;lactose-free;nut-free,\n", string(result))
+	})
+
+	t.Run("tsv", func(t *testing.T) {
+		result, err := Dishes(testPlans[1:]).FormatToCSV('\t')
+
+		assert.NoError(t, err)
+		assert.Equal(t, "date\tmeal\tdish\tingredients\tflags\tdiets\ttags\n2026-01-02\tObiad\tKotlet, ziemniaki\t2\tsuspicious\tgluten-free;lactose-free;nut-free\t\n", string(result))
+	})
+}
+
+func TestFormatToText(t *testing.T) {
+	table := Table{Header: []string{"dish", "servings"}, Rows: [][]string{{"Zupa", "12"}, {"Kotlet schabowy", "3"}}}
+
+	assert.Equal(t, "dish             servings\nZupa             12\nKotlet schabowy  3\n", string(table.FormatToText()))
+}
+
+func TestFormatToMarkdown(t *testing.T) {
+	table := Table{Header: []string{"dish", "servings"}, Rows: [][]string{{"Zupa | krem", "12"}}}
+
+	assert.Equal(t, "| dish | servings |\n| --- | --- |\n| Zupa \\| krem | 12 |\n", string(table.FormatToMarkdown()))
+}
+
+func TestFormatToJSON(t *testing.T) {
+	t.Run("objects keyed by header", func(t *testing.T) {
+		table := Table{Header: []string{"dish", "servings"}, Rows: [][]string{{"Zupa", "12"}}}
+
+		result, err := table.FormatToJSON()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "[\n  {\n    \"dish\": \"Zupa\",\n    \"servings\": \"12\"\n  }\n]\n", string(result))
+	})
+
+	t.Run("no rows", func(t *testing.T) {
+		result, err := Table{Header: []string{"dish"}}.FormatToJSON()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "[]\n", string(result))
+	})
+}
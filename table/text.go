@@ -0,0 +1,62 @@
+package table
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"text/tabwriter"
+)
+
+// FormatToText writes the table as aligned columns for reading in a terminal
+func (t Table) FormatToText() []byte {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	w.Write([]byte(strings.Join(t.Header, "\t") + "\n"))
+	for _, row := range t.Rows {
+		w.Write([]byte(strings.Join(row, "\t") + "\n"))
+	}
+	w.Flush()
+	return buf.Bytes()
+}
+
+// FormatToMarkdown writes the table as a Markdown pipe table
+func (t Table) FormatToMarkdown() []byte {
+	var sb strings.Builder
+	writeMarkdownRow(&sb, t.Header)
+	separators := make([]string, len(t.Header))
+	for i := range separators {
+		separators[i] = "---"
+	}
+	writeMarkdownRow(&sb, separators)
+	for _, row := range t.Rows {
+		writeMarkdownRow(&sb, row)
+	}
+	return []byte(sb.String())
+}
+
+func writeMarkdownRow(sb *strings.Builder, cells []string) {
+	sb.WriteString("|")
+	for _, cell := range cells {
+		sb.WriteString(" " + strings.ReplaceAll(cell, "|", "\\|") + " |")
+	}
+	sb.WriteString("\n")
+}
+
+// FormatToJSON writes the table as an array of objects keyed by the header
+func (t Table) FormatToJSON() ([]byte, error) {
+	records := make([]map[string]string, 0, len(t.Rows))
+	for _, row := range t.Rows {
+		record := make(map[string]string, len(t.Header))
+		for i, column := range t.Header {
+			if i < len(row) {
+				record[column] = row[i]
+			}
+		}
+		records = append(records, record)
+	}
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
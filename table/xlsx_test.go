@@ -0,0 +1,50 @@
+package table
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/toszr/dietician/meal"
+)
+
+func TestFormatToXLSX(t *testing.T) {
+	result, err := FormatToXLSX(testPlans, Dishes)
+	require.NoError(t, err)
+
+	zr, err := zip.NewReader(bytes.NewReader(result), int64(len(result)))
+	require.NoError(t, err)
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[f.Name] = string(data)
+	}
+
+	assert.Contains(t, files, "[Content_Types].xml")
+	assert.Contains(t, files["xl/workbook.xml"], `<sheet name="2026-01-01" sheetId="1" r:id="rId1"/><sheet name="2026-01-02" sheetId="2" r:id="rId2"/>`)
+	assert.Contains(t, files["xl/worksheets/sheet1.xml"], `<c r="C2" t="inlineStr"><is><t xml:space="preserve">Sernik</t></is></c><c r="D2"><v>2</v></c>`)
+	assert.Contains(t, files["xl/worksheets/sheet2.xml"], `<t xml:space="preserve">Kotlet, ziemniaki</t>`)
+	assert.NotContains(t, files["xl/worksheets/sheet2.xml"], "Sernik")
+}
+
+func TestSheetName(t *testing.T) {
+	used := map[string]bool{}
+	assert.Equal(t, "2026-01-01", sheetName(testPlans[0], 0, used))
+	assert.Equal(t, "2026-01-01 (2)", sheetName(testPlans[0], 1, used))
+	assert.Equal(t, "Dzień 3", sheetName(meal.Plan{}, 2, used))
+}
+
+func TestColumnName(t *testing.T) {
+	assert.Equal(t, "A", columnName(0))
+	assert.Equal(t, "Z", columnName(25))
+	assert.Equal(t, "AA", columnName(26))
+	assert.Equal(t, "AZ", columnName(51))
+}
@@ -0,0 +1,222 @@
+// Package vault writes menus as notes of an Obsidian or Logseq vault: day notes with YAML front matter
+// and [[wikilinks]] to dishes and ingredients, and one note per dish and ingredient listing the days it appears on.
+package vault
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/toszr/dietician/meal"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// Folders of the generated dish and ingredient notes, relative to the vault
+const (
+	DishesDir      = "dishes"
+	IngredientsDir = "ingredients"
+)
+
+// noteDateLayout names day notes like Obsidian's daily notes
+const noteDateLayout = "2006-01-02"
+
+// ErrNoDate is returned for plans without a date, which have no day note to link to
+var ErrNoDate = errors.New("plan has no date")
+
+// Day renders a day note: front matter with the date, the profile, meals and allergens, followed by the menu
+// with dishes and ingredients linked to their notes. Composition in parentheses stays plain text.
+// There is no per-day kcal property: the menu offers several dishes to choose from in every meal, so the
+// calories eaten are not known; the calorie target of the variant is written as calories when known.
+func Day(p meal.Plan, labels meal.Labels) string {
+	var sb strings.Builder
+
+	sb.WriteString("---\n")
+	if !p.Date.IsZero() {
+		sb.WriteString("date: " + p.Date.Format(noteDateLayout) + "\n")
+	}
+	for _, f := range p.Profile.Fields() {
+		sb.WriteString(f[0] + ": " + meal.YAMLString(f[1]) + "\n")
+	}
+	var meals []string
+	for _, m := range p.Meals {
+		meals = append(meals, m.Name)
+	}
+	writeList(&sb, "meals", meals)
+	writeList(&sb, "allergens", labels.AllergenNamesOf(p.Allergens()))
+	sb.WriteString("---\n\n")
+
+	for _, m := range p.Meals {
+		sb.WriteString("# " + m.Name + "\n\n")
+		for _, d := range m.Dishes {
+			sb.WriteString("## " + link(d.Name) + "\n")
+			for _, instruction := range d.Instructions {
+				sb.WriteString("> [!tip] " + instruction + "\n")
+			}
+			if len(d.Ingredients) > 0 {
+				sb.WriteString("**" + labels.Ingredients + ":**\n")
+				for _, ing := range d.Ingredients {
+					sb.WriteString("- " + linkIngredient(ing) + "\n")
+				}
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	return sb.String()
+}
+
+// occurrence is a dish served on a day
+type occurrence struct {
+	Day  string
+	Meal string
+	Dish string
+}
+
+// Notes renders the day notes of all plans together with a note for every dish and ingredient
+// listing the days it was served on. The result maps paths relative to the vault to note contents.
+func Notes(plans []meal.Plan, labels meal.Labels) (map[string]string, error) {
+	notes := map[string]string{}
+	dishes := map[string][]occurrence{}
+	dishIngredients := map[string][]string{}
+	dishTags := map[string][]string{}
+	ingredients := map[string][]occurrence{}
+
+	for _, p := range plans {
+		if p.Date.IsZero() {
+			return nil, ErrNoDate
+		}
+		day := dayNoteName(p)
+		notes[day+".md"] = Day(p, labels)
+
+		for _, m := range p.Meals {
+			for _, d := range m.Dishes {
+				o := occurrence{Day: day, Meal: m.Name, Dish: NoteName(d.Name)}
+				dishes[o.Dish] = append(dishes[o.Dish], o)
+				// The latest recipe describes the dish
+				dishIngredients[o.Dish] = d.Ingredients
+				dishTags[o.Dish] = d.Tags
+				seen := map[string]bool{}
+				for _, ing := range d.Ingredients {
+					name := NoteName(meal.ParseIngredient(ing).Name)
+					if name == "" || seen[name] {
+						continue
+					}
+					seen[name] = true
+					ingredients[name] = append(ingredients[name], o)
+				}
+			}
+		}
+	}
+
+	for name, occurrences := range dishes {
+		var sb strings.Builder
+		sb.WriteString("---\ntype: dish\n")
+		if tags := dishTags[name]; len(tags) > 0 {
+			writeList(&sb, "tags", obsidianTags(tags))
+		}
+		sb.WriteString("---\n\n# " + name + "\n\n")
+		if ings := dishIngredients[name]; len(ings) > 0 {
+			sb.WriteString("**" + labels.Ingredients + ":**\n")
+			for _, ing := range ings {
+				sb.WriteString("- " + linkIngredient(ing) + "\n")
+			}
+			sb.WriteString("\n")
+		}
+		for _, o := range sorted(occurrences) {
+			sb.WriteString("- [[" + o.Day + "]] · " + o.Meal + "\n")
+		}
+		notes[DishesDir+"/"+name+".md"] = sb.String()
+	}
+
+	for name, occurrences := range ingredients {
+		var sb strings.Builder
+		sb.WriteString("---\ntype: ingredient\n---\n\n# " + name + "\n\n")
+		for _, o := range sorted(occurrences) {
+			sb.WriteString("- [[" + o.Day + "]] · " + o.Meal + " · [[" + o.Dish + "]]\n")
+		}
+		notes[IngredientsDir+"/"+name+".md"] = sb.String()
+	}
+
+	return notes, nil
+}
+
+// sorted orders occurrences chronologically, keeping meals of a day in menu order
+func sorted(occurrences []occurrence) []occurrence {
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].Day < occurrences[j].Day
+	})
+	return occurrences
+}
+
+// forbiddenRegexp matches characters that cannot be used in note names and links
+var forbiddenRegexp = regexp.MustCompile(`[*"\\/<>:|?#^\[\]]+`)
+
+// dayNoteName names the note of a day like Obsidian's daily notes, followed by the profile of
+// the plan if known, so that the plans of several people and variants for the same day coexist
+func dayNoteName(p meal.Plan) string {
+	name := p.Date.Format(noteDateLayout)
+	if !p.Profile.IsZero() {
+		name += " " + NoteName(p.Profile.String())
+	}
+	return name
+}
+
+// NoteName returns the name of the note of a dish or canonical ingredient: the canonical name
+// in sentence case, without characters Obsidian does not allow in file names
+func NoteName(name string) string {
+	name = meal.CanonicalName(forbiddenRegexp.ReplaceAllString(name, " "))
+	r, size := utf8.DecodeRuneInString(name)
+	if r == utf8.RuneError {
+		return name
+	}
+	return cases.Upper(language.Polish).String(string(r)) + name[size:]
+}
+
+// link returns a wikilink to the note of name, keeping the original text as the alias if it differs
+func link(name string) string {
+	note := NoteName(name)
+	if note == "" {
+		return name
+	}
+	if note == name {
+		return "[[" + note + "]]"
+	}
+	return "[[" + note + "|" + strings.ReplaceAll(name, "|", " ") + "]]"
+}
+
+// linkIngredient links the name of an ingredient and appends its composition as plain text
+func linkIngredient(text string) string {
+	ing := meal.ParseIngredient(text)
+	if len(ing.Components) == 0 {
+		return link(ing.Name)
+	}
+	components := make([]string, 0, len(ing.Components))
+	for _, c := range ing.Components {
+		components = append(components, c.Text)
+	}
+	return link(ing.Name) + " (" + strings.Join(components, ", ") + ")"
+}
+
+// obsidianTags returns the tags with spaces replaced by hyphens, as Obsidian tags are single words
+func obsidianTags(tags []string) []string {
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		result = append(result, strings.Join(strings.Fields(tag), "-"))
+	}
+	return result
+}
+
+// writeList writes a YAML sequence property, or an empty flow sequence
+func writeList(sb *strings.Builder, key string, values []string) {
+	if len(values) == 0 {
+		sb.WriteString(key + ": []\n")
+		return
+	}
+	sb.WriteString(key + ":\n")
+	for _, v := range values {
+		sb.WriteString("  - " + meal.YAMLString(v) + "\n")
+	}
+}
@@ -0,0 +1,151 @@
+package vault
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/toszr/dietician/meal"
+)
+
+func samplePlans() []meal.Plan {
+	return []meal.Plan{
+		{
+			Date: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+			Meals: []meal.Meal{{
+				Name: "Obiad",
+				Dishes: []meal.Dish{{
+					Name:         "Zupa krem: dynia",
+					Ingredients:  []string{"Dynia hokaido", "Śmietanka 30%", "Bulion warzywny (woda, seler, sól)"},
+					Tags:         []string{"bez glutenu"},
+					Instructions: []string{"Lekko podgrzać"},
+				}},
+			}},
+		},
+		{
+			Date: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			Meals: []meal.Meal{
+				{Name: "Śniadanie", Dishes: []meal.Dish{{Name: "Owsianka", Ingredients: []string{"Płatki owsiane", "Dynia hokaido"}}}},
+				{Name: "II śniadanie", Dishes: []meal.Dish{{Name: "Owsianka", Ingredients: []string{"Płatki owsiane"}}}},
+			},
+		},
+	}
+}
+
+func TestDay(t *testing.T) {
+	t.Run("front matter and links", func(t *testing.T) {
+		expected := `---
+date: 2026-01-02
+meals:
+  - Obiad
+allergens:
+  - mleko
+  - seler
+---
+
+# Obiad
+
+## [[Zupa krem dynia|Zupa krem: dynia]]
+> [!tip] Lekko podgrzać
+**Składniki:**
+- [[Dynia hokaido]]
+- [[Śmietanka 30%]]
+- [[Bulion warzywny]] (woda, seler, sól)
+
+`
+		assert.Equal(t, expected, Day(samplePlans()[0], meal.PolishLabels))
+	})
+
+	t.Run("profile", func(t *testing.T) {
+		plan := meal.Plan{Profile: meal.Profile{Person: "Anna", Variant: "wege", Calories: 1500}}
+		assert.Equal(t, "---\nperson: Anna\nvariant: wege\ncalories: 1500\nmeals: []\nallergens: []\n---\n\n", Day(plan, meal.PolishLabels))
+	})
+
+	t.Run("ingredients with nested or stray parentheses", func(t *testing.T) {
+		plan := meal.Plan{Meals: []meal.Meal{{Name: "Obiad", Dishes: []meal.Dish{{
+			Name:        "Kanapka",
+			Ingredients: []string{"Chleb (mąka (pszenna, żytnia), sól)", "Kwas askorbinowy)"},
+		}}}}}
+
+		day := Day(plan, meal.PolishLabels)
+
+		assert.Contains(t, day, "- [[Chleb]] (mąka (pszenna, żytnia), sól)\n- [[Kwas askorbinowy]]\n")
+	})
+
+	t.Run("quoted profile", func(t *testing.T) {
+		plan := meal.Plan{Profile: meal.Profile{Person: "Anna #2: mama"}}
+		assert.Equal(t, "---\nperson: \"Anna #2: mama\"\nmeals: []\nallergens: []\n---\n\n", Day(plan, meal.PolishLabels))
+	})
+
+	t.Run("empty plan", func(t *testing.T) {
+		assert.Equal(t, "---\nmeals: []\nallergens: []\n---\n\n", Day(meal.Plan{}, meal.PolishLabels))
+	})
+}
+
+func TestNotes(t *testing.T) {
+	t.Run("back-references across days", func(t *testing.T) {
+		notes, err := Notes(samplePlans(), meal.PolishLabels)
+		require.NoError(t, err)
+
+		assert.Contains(t, notes, "2026-01-01.md")
+		assert.Contains(t, notes, "2026-01-02.md")
+		assert.Equal(t, `---
+type: ingredient
+---
+
+# Dynia hokaido
+
+- [[2026-01-01]] · Śniadanie · [[Owsianka]]
+- [[2026-01-02]] · Obiad · [[Zupa krem dynia]]
+`, notes["ingredients/Dynia hokaido.md"])
+		assert.Equal(t, `---
+type: dish
+---
+
+# Owsianka
+
+**Składniki:**
+- [[Płatki owsiane]]
+
+- [[2026-01-01]] · Śniadanie
+- [[2026-01-01]] · II śniadanie
+`, notes["dishes/Owsianka.md"])
+		assert.True(t, strings.HasPrefix(notes["dishes/Zupa krem dynia.md"], "---\ntype: dish\ntags:\n  - bez-glutenu\n---\n"))
+		assert.NotContains(t, notes, "ingredients/Woda.md", "composition is not linked")
+	})
+
+	t.Run("plans of several people for the same day", func(t *testing.T) {
+		plans := samplePlans()[:1]
+		plans = append(plans, plans[0], plans[0])
+		plans[1].Profile.Person = "Anna"
+		plans[2].Profile.Person = "Piotr"
+
+		notes, err := Notes(plans, meal.PolishLabels)
+		require.NoError(t, err)
+
+		assert.Contains(t, notes, "2026-01-02.md")
+		assert.Contains(t, notes, "2026-01-02 Anna.md")
+		assert.Contains(t, notes, "2026-01-02 Piotr.md")
+		assert.Contains(t, notes["dishes/Zupa krem dynia.md"], "- [[2026-01-02 Anna]] · Obiad\n")
+	})
+
+	t.Run("plans of several variants for the same day", func(t *testing.T) {
+		plans := samplePlans()[:1]
+		plans = append(plans, plans[0])
+		plans[0].Profile = meal.Profile{Variant: "wege", Calories: 1500}
+		plans[1].Profile = meal.Profile{Variant: "sport", Calories: 2500}
+
+		notes, err := Notes(plans, meal.PolishLabels)
+		require.NoError(t, err)
+
+		assert.Contains(t, notes, "2026-01-02 Wege 1500 kcal.md")
+		assert.Contains(t, notes, "2026-01-02 Sport 2500 kcal.md")
+	})
+
+	t.Run("plan without a date", func(t *testing.T) {
+		_, err := Notes([]meal.Plan{{}}, meal.PolishLabels)
+		assert.ErrorIs(t, err, ErrNoDate)
+	})
+}